@@ -94,6 +94,8 @@ type Config struct {
 	terraformVersion string
 	fabricClient     *v4.APIClient
 	FabricAuthToken  string
+
+	plannedDevices plannedDeviceCapacity
 }
 
 // Load function validates configuration structure fields and configures
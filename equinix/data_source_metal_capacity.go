@@ -0,0 +1,89 @@
+package equinix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+func dataSourceMetalCapacity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetalCapacityRead,
+		Schema: map[string]*schema.Schema{
+			"metro": {
+				Type:        schema.TypeString,
+				Description: "The metro code to check the capacity in",
+				Required:    true,
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Description: "The device plan slug to check the capacity of",
+				Required:    true,
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Description:  "The number of devices that need to be deployed",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"available": {
+				Type:        schema.TypeBool,
+				Description: "Whether the quantity of devices of the plan can be deployed in the metro",
+				Computed:    true,
+			},
+			"level": {
+				Type:        schema.TypeString,
+				Description: "The current capacity level of the plan in the metro, one of normal, limited or unavailable",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceMetalCapacityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).metal
+	metro := d.Get("metro").(string)
+	plan := d.Get("plan").(string)
+	quantity := d.Get("quantity").(int)
+
+	available, err := checkMetroCapacity(client, metro, plan, quantity)
+	if err != nil {
+		return err
+	}
+
+	report, _, err := client.CapacityService.ListMetros()
+	if err != nil {
+		return friendlyError(err)
+	}
+	level := ""
+	if report != nil {
+		level = (*report)[metro][plan].Level
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s-%d", metro, plan, quantity))
+	return setMap(d, map[string]interface{}{
+		"available": available,
+		"level":     level,
+	})
+}
+
+// checkMetroCapacity reports whether quantity devices of the plan can be
+// deployed in the metro.
+func checkMetroCapacity(client *packngo.Client, metro, plan string, quantity int) (bool, error) {
+	ci := &packngo.CapacityInput{
+		Servers: []packngo.ServerInfo{{Metro: metro, Plan: plan, Quantity: quantity}},
+	}
+	res, _, err := client.CapacityService.CheckMetros(ci)
+	if err != nil {
+		return false, friendlyError(err)
+	}
+	for _, s := range res.Servers {
+		if !s.Available {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,44 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMetalCapacity_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMetalCapacityConfig("da", "c3.small.x86", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_capacity.test", "available", "true"),
+					resource.TestCheckResourceAttrSet(
+						"data.equinix_metal_capacity.test", "level"),
+				),
+			},
+			{
+				Config: testAccDataSourceMetalCapacityConfig("da", "c3.small.x86", 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_capacity.test", "available", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMetalCapacityConfig(metro, plan string, quantity int) string {
+	return fmt.Sprintf(`
+data "equinix_metal_capacity" "test" {
+    metro    = "%s"
+    plan     = "%s"
+    quantity = %d
+}
+`, metro, plan, quantity)
+}
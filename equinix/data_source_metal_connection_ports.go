@@ -0,0 +1,40 @@
+package equinix
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourceMetalConnectionPorts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetalConnectionPortsRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the connection whose ports to list",
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Elem:        connectionPortSchema(),
+				Computed:    true,
+				Description: "List of connection ports - primary (`ports[0]`) and secondary (`ports[1]`)",
+			},
+		},
+	}
+}
+
+func dataSourceMetalConnectionPortsRead(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	connID := d.Get("connection_id").(string)
+	ports, _, err := client.Connections.Ports(connID, &packngo.GetOptions{Includes: []string{"virtual_circuits"}})
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(connID)
+	return d.Set("ports", getConnectionPorts(ports))
+}
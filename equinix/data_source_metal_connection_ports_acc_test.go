@@ -0,0 +1,57 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMetalConnectionPorts_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceMetalConnectionPortsConfig_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_connection_ports.test", "ports.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_connection.test", "ports.0.id",
+						"data.equinix_metal_connection_ports.test", "ports.0.id"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_connection_ports.test", "ports.0.role", "primary"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_connection_ports.test", "ports.1.role", "secondary"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceMetalConnectionPortsConfig_basic(r int) string {
+	return fmt.Sprintf(`
+		resource "equinix_metal_project" "test" {
+			name = "tfacc-conn-project-%d"
+		}
+
+		resource "equinix_metal_connection" "test" {
+			name               = "tfacc-conn-%d"
+			project_id         = equinix_metal_project.test.id
+			type               = "shared"
+			redundancy         = "redundant"
+			metro              = "sv"
+			speed              = "50Mbps"
+			service_token_type = "a_side"
+		}
+
+		data "equinix_metal_connection_ports" "test" {
+			connection_id = equinix_metal_connection.test.id
+		}`,
+		r, r)
+}
@@ -0,0 +1,166 @@
+package equinix
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func connectionVirtualCircuitSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the virtual circuit",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the virtual circuit",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the virtual circuit",
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the connection port the virtual circuit belongs to",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the project to which the virtual circuit belongs",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the virtual circuit",
+			},
+			"speed": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Speed of the virtual circuit in bits per second",
+			},
+			"vnid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "VNID of the VLAN attached to the virtual circuit",
+			},
+			"nni_vlan": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Equinix Metal network-to-network VLAN ID",
+			},
+			"nni_vnid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Nni VLAN ID parameter, see https://metal.equinix.com/developers/docs/networking/fabric/",
+			},
+			"vlan_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the VLAN attached to the virtual circuit",
+			},
+			"vrf_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the VRF attached to the virtual circuit",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tags attached to the virtual circuit",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceMetalConnectionVirtualCircuits() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetalConnectionVirtualCircuitsRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the connection whose virtual circuits to list",
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the connection port to list the virtual circuits of. If omitted, virtual circuits of all ports of the connection are listed",
+			},
+			"virtual_circuits": {
+				Type:        schema.TypeList,
+				Elem:        connectionVirtualCircuitSchema(),
+				Computed:    true,
+				Description: "List of virtual circuits of the connection",
+			},
+		},
+	}
+}
+
+func dataSourceMetalConnectionVirtualCircuitsRead(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	connID := d.Get("connection_id").(string)
+	portIDs := []string{}
+	if portID, ok := d.GetOk("port_id"); ok {
+		portIDs = append(portIDs, portID.(string))
+	} else {
+		ports, _, err := client.Connections.Ports(connID, nil)
+		if err != nil {
+			return friendlyError(err)
+		}
+		for _, p := range ports {
+			portIDs = append(portIDs, p.ID)
+		}
+	}
+
+	vcs := []map[string]interface{}{}
+	for _, portID := range portIDs {
+		portVCs, _, err := client.Connections.VirtualCircuits(connID, portID, &packngo.GetOptions{Includes: []string{"project", "virtual_network", "vrf"}})
+		if err != nil {
+			return friendlyError(err)
+		}
+		vcs = append(vcs, getConnectionVirtualCircuits(portID, portVCs)...)
+	}
+
+	d.SetId(connID)
+	return d.Set("virtual_circuits", vcs)
+}
+
+func getConnectionVirtualCircuits(portID string, vcs []packngo.VirtualCircuit) []map[string]interface{} {
+	ret := make([]map[string]interface{}, 0, len(vcs))
+	for _, vc := range vcs {
+		rawVC := map[string]interface{}{
+			"id":          vc.ID,
+			"name":        vc.Name,
+			"description": vc.Description,
+			"port_id":     portID,
+			"status":      string(vc.Status),
+			"speed":       strconv.Itoa(vc.Speed),
+			"vnid":        vc.VNID,
+			"nni_vlan":    vc.NniVLAN,
+			"nni_vnid":    vc.NniVNID,
+			"tags":        vc.Tags,
+		}
+		if vc.Project != nil {
+			rawVC["project_id"] = vc.Project.ID
+		}
+		if vc.VirtualNetwork != nil {
+			rawVC["vlan_id"] = vc.VirtualNetwork.ID
+		}
+		if vc.VRF != nil {
+			rawVC["vrf_id"] = vc.VRF.ID
+		}
+		ret = append(ret, rawVC)
+	}
+	return ret
+}
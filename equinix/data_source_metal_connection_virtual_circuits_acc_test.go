@@ -0,0 +1,77 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMetalConnectionVirtualCircuits_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceMetalConnectionVirtualCircuitsConfig_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_connection_virtual_circuits.all", "virtual_circuits.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_connection_virtual_circuits.primary", "virtual_circuits.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_connection.test", "ports.0.id",
+						"data.equinix_metal_connection_virtual_circuits.primary", "virtual_circuits.0.port_id"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_vlan.test1", "id",
+						"data.equinix_metal_connection_virtual_circuits.primary", "virtual_circuits.0.vlan_id"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceMetalConnectionVirtualCircuitsConfig_basic(r int) string {
+	return fmt.Sprintf(`
+		resource "equinix_metal_project" "test" {
+			name = "tfacc-conn-project-%d"
+		}
+
+		resource "equinix_metal_vlan" "test1" {
+			project_id = equinix_metal_project.test.id
+			metro      = "sv"
+		}
+
+		resource "equinix_metal_vlan" "test2" {
+			project_id = equinix_metal_project.test.id
+			metro      = "sv"
+		}
+
+		resource "equinix_metal_connection" "test" {
+			name               = "tfacc-conn-%d"
+			project_id         = equinix_metal_project.test.id
+			type               = "shared"
+			redundancy         = "redundant"
+			metro              = "sv"
+			speed              = "50Mbps"
+			service_token_type = "a_side"
+			vlans              = [
+				equinix_metal_vlan.test1.vxlan,
+				equinix_metal_vlan.test2.vxlan
+			]
+		}
+
+		data "equinix_metal_connection_virtual_circuits" "all" {
+			connection_id = equinix_metal_connection.test.id
+		}
+
+		data "equinix_metal_connection_virtual_circuits" "primary" {
+			connection_id = equinix_metal_connection.test.id
+			port_id       = equinix_metal_connection.test.ports[0].id
+		}`,
+		r, r)
+}
@@ -0,0 +1,144 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/equinix/terraform-provider-equinix/equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var hardwareReservationIncludes = []string{"project", "facility.metro"}
+
+func hardwareReservationRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the hardware reservation",
+		},
+		"short_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Reservation short ID",
+		},
+		"project_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UUID of project this reservation is scoped to",
+		},
+		"device_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UUID of device occupying the reservation",
+		},
+		"plan": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Plan type for the reservation",
+		},
+		"facility": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Facility for the reservation",
+		},
+		"metro": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Metro for the reservation",
+		},
+		"provisionable": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Flag indicating whether the reserved server is provisionable or not. Spare devices can't be provisioned unless they are activated first",
+		},
+		"spare": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Flag indicating whether the Hardware Reservation is a spare. Spare Hardware Reservations are used when a Hardware Reservations requires service from Metal Equinix",
+		},
+		"need_of_service": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether this Device requires assistance from Equinix Metal",
+		},
+		"switch_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Switch short ID, can be used to determine if two devices are connected to the same switch",
+		},
+		"custom_rate": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Amount that will be charged for every billing_cycle, agreed with Equinix Metal",
+		},
+	}
+}
+
+func dataSourceMetalHardwareReservations() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               hardwareReservationRecordSchema(),
+		ResultAttributeName:        "hardware_reservations",
+		ResultAttributeDescription: "List of hardware reservations that match specified filters",
+		FlattenRecord:              flattenHardwareReservation,
+		GetRecords:                 getHardwareReservations,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Description: "The id of the project to query for hardware reservations",
+				Required:    true,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+func getHardwareReservations(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*Config).metalgo
+	projectID := extra["project_id"].(string)
+
+	reservations, err := client.HardwareReservationsApi.FindProjectHardwareReservations(
+		context.Background(), projectID).Include(hardwareReservationIncludes).ExecuteWithPagination()
+	if err != nil {
+		return nil, err
+	}
+
+	reservationsIf := []interface{}{}
+	for _, hr := range reservations.HardwareReservations {
+		reservationsIf = append(reservationsIf, hr)
+	}
+	return reservationsIf, nil
+}
+
+func flattenHardwareReservation(rawReservation interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	hr, ok := rawReservation.(metalv1.HardwareReservation)
+	if !ok {
+		return nil, fmt.Errorf("expected hardware reservation to be of type metalv1.HardwareReservation, got %T", rawReservation)
+	}
+	return getHardwareReservationMap(hr), nil
+}
+
+func getHardwareReservationMap(hr metalv1.HardwareReservation) map[string]interface{} {
+	facility := hr.GetFacility()
+	metro := facility.GetMetro()
+	deviceID := ""
+	if hr.Device != nil {
+		deviceID = path.Base(hr.Device.GetHref())
+	}
+	return map[string]interface{}{
+		"id":              hr.GetId(),
+		"short_id":        hr.GetShortId(),
+		"project_id":      hr.Project.GetId(),
+		"device_id":       deviceID,
+		"plan":            hr.Plan.GetSlug(),
+		"facility":        facility.GetCode(),
+		"metro":           metro.GetCode(),
+		"provisionable":   hr.GetProvisionable(),
+		"spare":           hr.GetSpare(),
+		"need_of_service": hr.GetNeedOfService(),
+		"switch_uuid":     hr.GetSwitchUuid(),
+		"custom_rate":     hr.GetCustomRate(),
+	}
+}
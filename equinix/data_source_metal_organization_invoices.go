@@ -0,0 +1,150 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/equinix/terraform-provider-equinix/equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const invoicesPerPage = 100
+
+func organizationInvoiceRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the invoice",
+		},
+		"number": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Invoice number",
+		},
+		"reference_number": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Reference number of the invoice",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the invoice, e.g. paid",
+		},
+		"period": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Billing period of the invoice in the YYYY-MM format",
+		},
+		"target_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date of the billing period the invoice is for",
+		},
+		"created_on": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date the invoice was issued",
+		},
+		"due_on": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date the invoice is due",
+		},
+		"currency": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Currency of the invoice amounts",
+		},
+		"total": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Total amount of the invoice",
+		},
+		"balance": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Amount left to pay",
+		},
+		"credits_applied": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Amount of credits applied to the invoice",
+		},
+		"project_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the project the invoice is for, if the invoice is for a single project",
+		},
+	}
+}
+
+func dataSourceMetalOrganizationInvoices() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               organizationInvoiceRecordSchema(),
+		ResultAttributeName:        "invoices",
+		ResultAttributeDescription: "List of organization invoices that match specified filters",
+		FlattenRecord:              flattenOrganizationInvoice,
+		GetRecords:                 getOrganizationInvoices,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:        schema.TypeString,
+				Description: "The id of the organization to query for invoices",
+				Required:    true,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+func getOrganizationInvoices(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*Config).metalgo
+	orgID := extra["organization_id"].(string)
+
+	invoicesIf := []interface{}{}
+	for page := int32(1); ; page++ {
+		invoices, resp, err := client.InvoicesApi.FindOrganizationInvoices(context.Background(), orgID).
+			Page(page).PerPage(invoicesPerPage).Execute()
+		if err != nil {
+			return nil, friendlyErrorForMetalGo(err, resp)
+		}
+		for _, inv := range invoices.GetInvoices() {
+			invoicesIf = append(invoicesIf, inv)
+		}
+		if len(invoices.GetInvoices()) < invoicesPerPage {
+			return invoicesIf, nil
+		}
+	}
+}
+
+func flattenOrganizationInvoice(rawInvoice interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	inv, ok := rawInvoice.(metalv1.Invoice)
+	if !ok {
+		return nil, fmt.Errorf("expected invoice to be of type metalv1.Invoice, got %T", rawInvoice)
+	}
+	period := inv.GetTargetDate()
+	if len(period) >= 7 {
+		period = period[:7]
+	}
+	projectID := ""
+	if inv.Project != nil {
+		projectID = inv.Project.GetId()
+	}
+	return map[string]interface{}{
+		"id":               inv.GetId(),
+		"number":           inv.GetNumber(),
+		"reference_number": inv.GetReferenceNumber(),
+		"status":           inv.GetStatus(),
+		"period":           period,
+		"target_date":      inv.GetTargetDate(),
+		"created_on":       inv.GetCreatedOn(),
+		"due_on":           inv.GetDueOn(),
+		"currency":         inv.GetCurrency(),
+		"total":            float64(inv.GetAmount()),
+		"balance":          float64(inv.GetBalance()),
+		"credits_applied":  float64(inv.GetCreditsApplied()),
+		"project_id":       projectID,
+	}, nil
+}
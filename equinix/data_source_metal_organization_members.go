@@ -0,0 +1,149 @@
+package equinix
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/equinix/terraform-provider-equinix/equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func organizationMemberRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the membership, or of the invitation for invited users",
+		},
+		"email": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Email address of the member or invitee",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the membership ('invited' when an invitation is open, 'active' when the user is an organization member)",
+		},
+		"roles": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Organization roles (owner, collaborator, limited_collaborator, billing)",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"projects_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Project IDs the member has, or is invited to have, access to within the organization",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"user_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the user (only known in the active state)",
+		},
+		"invited_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The user id of the user that sent the invitation (only known in the invitation stage)",
+		},
+		"created": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the invitation was created (only known in the invitation stage)",
+		},
+		"updated": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the invitation was updated (only known in the invitation stage)",
+		},
+	}
+}
+
+func dataSourceMetalOrganizationMembers() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               organizationMemberRecordSchema(),
+		ResultAttributeName:        "members",
+		ResultAttributeDescription: "List of organization members and open invitations that match specified filters",
+		FlattenRecord:              flattenOrganizationMember,
+		GetRecords:                 getOrganizationMembers,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:        schema.TypeString,
+				Description: "The id of the organization to query for members and invitations",
+				Required:    true,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+func getOrganizationMembers(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*Config).metal
+	orgID := extra["organization_id"].(string)
+
+	members, _, err := client.Members.List(orgID, &packngo.GetOptions{Includes: []string{"user"}})
+	if err != nil {
+		return nil, friendlyError(err)
+	}
+	invitations, _, err := client.Invitations.List(orgID, nil)
+	if err != nil {
+		return nil, friendlyError(err)
+	}
+
+	membersIf := []interface{}{}
+	for i := range members {
+		membersIf = append(membersIf, member{Member: &members[i]})
+	}
+	for i := range invitations {
+		membersIf = append(membersIf, member{Invitation: &invitations[i]})
+	}
+	return membersIf, nil
+}
+
+func flattenOrganizationMember(rawMember interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	m, ok := rawMember.(member)
+	if !ok {
+		return nil, fmt.Errorf("expected member to be of type member, got %T", rawMember)
+	}
+
+	projectIDs := []string{}
+	if m.isMember() {
+		for _, project := range m.Member.Projects {
+			projectIDs = append(projectIDs, path.Base(project.URL))
+		}
+		return map[string]interface{}{
+			"id":           m.Member.ID,
+			"email":        m.Member.User.Email,
+			"state":        "active",
+			"roles":        m.Member.Roles,
+			"projects_ids": projectIDs,
+			"user_id":      m.Member.User.ID,
+		}, nil
+	}
+
+	for _, project := range m.Invitation.Projects {
+		projectIDs = append(projectIDs, path.Base(project.Href))
+	}
+	invitedBy, created, updated := "", "", ""
+	if m.Invitation.InvitedBy.Href != "" {
+		invitedBy = path.Base(m.Invitation.InvitedBy.Href)
+	}
+	if m.Invitation.CreatedAt != nil {
+		created = m.Invitation.CreatedAt.String()
+	}
+	if m.Invitation.UpdatedAt != nil {
+		updated = m.Invitation.UpdatedAt.String()
+	}
+	return map[string]interface{}{
+		"id":           m.Invitation.ID,
+		"email":        m.Invitation.Invitee,
+		"state":        "invited",
+		"roles":        m.Invitation.Roles,
+		"projects_ids": projectIDs,
+		"invited_by":   invitedBy,
+		"created":      created,
+		"updated":      updated,
+	}, nil
+}
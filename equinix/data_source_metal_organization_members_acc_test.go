@@ -0,0 +1,55 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMetalOrganizationMembers_invited(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalOrganizationCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMetalOrganizationMembersConfig_invited(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_organization_members.test", "members.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_organization_members.test", "members.0.email",
+						"tfacc.testing.member@equinixmetal.com"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_organization_members.test", "members.0.roles.0",
+						"limited_collaborator"),
+					resource.TestCheckResourceAttrPair(
+						"data.equinix_metal_organization_members.test", "members.0.projects_ids.0",
+						"equinix_metal_project.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMetalOrganizationMembersConfig_invited(r int) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+data "equinix_metal_organization_members" "test" {
+	organization_id = equinix_metal_organization.test.id
+	filter {
+		attribute = "state"
+		values    = ["invited"]
+	}
+	depends_on = [equinix_metal_organization_member.member]
+}
+`, testAccResourceMetalOrganizationMember_basic(r), testAccResourceMetalOrganizationMember_member())
+}
@@ -0,0 +1,141 @@
+package equinix
+
+import (
+	"context"
+	"path"
+	"time"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func projectBGPSessionSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "UUID of the BGP session",
+				Computed:    true,
+			},
+			"device_id": {
+				Type:        schema.TypeString,
+				Description: "UUID of the device the BGP session belongs to",
+				Computed:    true,
+			},
+			"address_family": {
+				Type:        schema.TypeString,
+				Description: "BGP session address family, ipv4 or ipv6",
+				Computed:    true,
+			},
+			"default_route": {
+				Type:        schema.TypeBool,
+				Description: "Whether the default route is announced to the device",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the BGP session, one of up, down or unknown",
+				Computed:    true,
+			},
+			"learned_routes": {
+				Type:        schema.TypeList,
+				Description: "Routes learned from the device over the BGP session",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"prefix_count": {
+				Type:        schema.TypeInt,
+				Description: "Number of prefixes learned over the BGP session",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The timestamp for when the BGP session was created",
+				Computed:    true,
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Description: "The timestamp for the last time the BGP session was updated",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceMetalProjectBGPSessions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMetalProjectBGPSessionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Description: "UUID of BGP-enabled project whose sessions to list",
+				Required:    true,
+			},
+			"bgp_sessions": {
+				Type:        schema.TypeList,
+				Description: "Array of BGP session records of every device in the project",
+				Computed:    true,
+				Elem:        projectBGPSessionSchema(),
+			},
+			"sessions_up": {
+				Type:        schema.TypeInt,
+				Description: "Number of BGP sessions in the project with status up",
+				Computed:    true,
+			},
+			"sessions_down": {
+				Type:        schema.TypeInt,
+				Description: "Number of BGP sessions in the project with status other than up",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceMetalProjectBGPSessionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).metalgo
+	projectID := d.Get("project_id").(string)
+
+	sessionsRaw, _, err := client.BGPApi.FindProjectBgpSessions(ctx, projectID).Execute()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sessions := getProjectBgpSessions(sessionsRaw)
+	up := 0
+	for _, s := range sessions {
+		if s["status"] == string(metalv1.BGPSESSIONSTATUS_UP) {
+			up++
+		}
+	}
+
+	d.SetId(projectID)
+	return diag.FromErr(setMap(d, map[string]interface{}{
+		"bgp_sessions":  sessions,
+		"sessions_up":   up,
+		"sessions_down": len(sessions) - up,
+	}))
+}
+
+func getProjectBgpSessions(sl *metalv1.BgpSessionList) []map[string]interface{} {
+	ret := make([]map[string]interface{}, 0, len(sl.BgpSessions))
+	for _, s := range sl.BgpSessions {
+		session := map[string]interface{}{
+			"id":             s.GetId(),
+			"device_id":      path.Base(s.Device.GetHref()),
+			"address_family": string(s.GetAddressFamily()),
+			"default_route":  s.GetDefaultRoute(),
+			"status":         string(s.GetStatus()),
+			"learned_routes": s.GetLearnedRoutes(),
+			"prefix_count":   len(s.GetLearnedRoutes()),
+		}
+		if s.CreatedAt != nil {
+			session["created_at"] = s.GetCreatedAt().Format(time.RFC3339)
+		}
+		if s.UpdatedAt != nil {
+			session["updated_at"] = s.GetUpdatedAt().Format(time.RFC3339)
+		}
+		ret = append(ret, session)
+	}
+	return ret
+}
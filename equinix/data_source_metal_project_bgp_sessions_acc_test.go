@@ -0,0 +1,44 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMetalProjectBgpSessions(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalProjectCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMetalProjectBgpSessionsConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.equinix_metal_project_bgp_sessions.test", "project_id",
+						"equinix_metal_project.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_project_bgp_sessions.test", "bgp_sessions.#", "0"),
+					resource.TestCheckResourceAttr(
+						"data.equinix_metal_project_bgp_sessions.test", "sessions_down", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMetalProjectBgpSessionsConfig(r int) string {
+	return fmt.Sprintf(`
+%s
+
+data "equinix_metal_project_bgp_sessions" "test" {
+	project_id = equinix_metal_project.foobar.id
+}
+`, testAccMetalProjectConfig_BGP(r, "fdsfsdf432F"))
+}
@@ -0,0 +1,143 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/equinix/terraform-provider-equinix/equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func projectUsageRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the used resource, e.g. the hostname of a device",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the used resource, e.g. Instance or Outbound Bandwidth",
+		},
+		"plan": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Plan of the used resource",
+		},
+		"plan_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Version of the plan",
+		},
+		"facility": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Facility of the used resource",
+		},
+		"quantity": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Used quantity in units, e.g. hours of a device",
+		},
+		"unit": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Unit of the quantity, e.g. hour or GB",
+		},
+		"price": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Price of a unit",
+		},
+		"total": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Cost of the usage",
+		},
+	}
+}
+
+func dataSourceMetalProjectUsage() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               projectUsageRecordSchema(),
+		ResultAttributeName:        "usages",
+		ResultAttributeDescription: "List of project usages that match specified filters",
+		FlattenRecord:              flattenProjectUsage,
+		GetRecords:                 getProjectUsages,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Description: "The id of the project to query for usages",
+				Required:    true,
+			},
+			"created_after": {
+				Type:         schema.TypeString,
+				Description:  "Only usages created after this RFC3339 timestamp, e.g. 2023-06-01T00:00:00Z",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"created_before": {
+				Type:         schema.TypeString,
+				Description:  "Only usages created before this RFC3339 timestamp, e.g. 2023-07-01T00:00:00Z",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+func getProjectUsages(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*Config).metalgo
+	projectID := extra["project_id"].(string)
+
+	req := client.UsagesApi.FindProjectUsage(context.Background(), projectID)
+	if after := extra["created_after"].(string); after != "" {
+		req = req.CreatedAfter(after)
+	}
+	if before := extra["created_before"].(string); before != "" {
+		req = req.CreatedBefore(before)
+	}
+	usages, resp, err := req.Execute()
+	if err != nil {
+		return nil, friendlyErrorForMetalGo(err, resp)
+	}
+
+	usagesIf := []interface{}{}
+	for _, u := range usages.GetUsages() {
+		usagesIf = append(usagesIf, u)
+	}
+	return usagesIf, nil
+}
+
+// parseUsageAmount parses the amounts which the API reports as strings, an
+// amount which can't be parsed is reported as 0.
+func parseUsageAmount(amount string) float64 {
+	v, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func flattenProjectUsage(rawUsage interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	u, ok := rawUsage.(metalv1.ProjectUsage)
+	if !ok {
+		return nil, fmt.Errorf("expected project usage to be of type metalv1.ProjectUsage, got %T", rawUsage)
+	}
+	return map[string]interface{}{
+		"name":         u.GetName(),
+		"type":         u.GetType(),
+		"plan":         u.GetPlan(),
+		"plan_version": u.GetPlanVersion(),
+		"facility":     u.GetFacility(),
+		"quantity":     parseUsageAmount(u.GetQuantity()),
+		"unit":         u.GetUnit(),
+		"price":        parseUsageAmount(u.GetPrice()),
+		"total":        parseUsageAmount(u.GetTotal()),
+	}, nil
+}
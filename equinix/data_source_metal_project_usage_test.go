@@ -0,0 +1,47 @@
+package equinix
+
+import (
+	"testing"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenProjectUsage(t *testing.T) {
+	// given
+	usage := metalv1.ProjectUsage{
+		Name:     metalv1.PtrString("render-001"),
+		Type:     metalv1.PtrString("Instance"),
+		Plan:     metalv1.PtrString("m3.large.x86"),
+		Quantity: metalv1.PtrString("720.5"),
+		Unit:     metalv1.PtrString("hour"),
+		Price:    metalv1.PtrString("3.1"),
+		Total:    metalv1.PtrString("not a number"),
+	}
+	// when
+	m, err := flattenProjectUsage(usage, nil, nil)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "render-001", m["name"])
+	assert.Equal(t, 720.5, m["quantity"])
+	assert.Equal(t, 3.1, m["price"])
+	assert.Equal(t, 0.0, m["total"])
+}
+
+func Test_flattenOrganizationInvoice(t *testing.T) {
+	// given
+	invoice := metalv1.Invoice{
+		Id:         metalv1.PtrString("inv-id"),
+		Number:     metalv1.PtrString("1234"),
+		Status:     metalv1.PtrString("paid"),
+		TargetDate: metalv1.PtrString("2023-06-01"),
+		Amount:     metalv1.PtrFloat32(125.5),
+	}
+	// when
+	m, err := flattenOrganizationInvoice(invoice, nil, nil)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-06", m["period"])
+	assert.Equal(t, 125.5, m["total"])
+	assert.Equal(t, "", m["project_id"])
+}
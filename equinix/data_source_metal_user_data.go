@@ -0,0 +1,169 @@
+package equinix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMetalUserData() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetalUserDataRead,
+		Schema: map[string]*schema.Schema{
+			"part": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Parts of the multipart cloud-init user data, in the order they are processed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Content of the part",
+						},
+						"content_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "text/cloud-config",
+							Description:  "MIME type of the part, e.g. text/cloud-config or text/x-shellscript",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"filename": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Filename of the part",
+						},
+						"merge_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Cloud-init merge type of the part, e.g. list(append)+dict(recurse_array)+str()",
+						},
+					},
+				},
+			},
+			"gzip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Compress the user data with gzip. Requires base64_encode",
+			},
+			"base64_encode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Encode the user data with base64",
+			},
+			"boundary": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "MIMEBOUNDARY",
+				Description: "Boundary between the parts of the multipart user data",
+			},
+			"rendered": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user data to pass to the user_data argument of a device",
+			},
+		},
+	}
+}
+
+func dataSourceMetalUserDataRead(d *schema.ResourceData, meta interface{}) error {
+	gz := d.Get("gzip").(bool)
+	b64 := d.Get("base64_encode").(bool)
+	if gz && !b64 {
+		return fmt.Errorf("gzip user data must be base64 encoded, set base64_encode to true")
+	}
+
+	rendered, err := renderMultipartUserData(d.Get("part").([]interface{}), d.Get("boundary").(string))
+	if err != nil {
+		return err
+	}
+
+	if gz {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write([]byte(rendered)); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		rendered = buf.String()
+	}
+	if b64 {
+		rendered = base64.StdEncoding.EncodeToString([]byte(rendered))
+	}
+	if len(rendered) > userDataMaxSize {
+		return fmt.Errorf("rendered user data is %d bytes, the limit is %d bytes", len(rendered), userDataMaxSize)
+	}
+
+	d.SetId(strconv.Itoa(hashcodeString(rendered)))
+	return d.Set("rendered", rendered)
+}
+
+func renderMultipartUserData(parts []interface{}, boundary string) (string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return "", fmt.Errorf("invalid boundary %q: %s", boundary, err)
+	}
+
+	buf.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\r\n", boundary))
+	buf.WriteString("MIME-Version: 1.0\r\n\r\n")
+
+	for i, raw := range parts {
+		part := raw.(map[string]interface{})
+		content := part["content"].(string)
+		contentType := part["content_type"].(string)
+		if err := checkUserDataPart(contentType, content); err != nil {
+			return "", fmt.Errorf("part %d: %s", i, err)
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+		if filename := part["filename"].(string); filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		}
+		if mergeType := part["merge_type"].(string); mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+
+		w, err := mw.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			return "", err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// checkUserDataPart checks the syntax of a part of multipart user data by its
+// MIME type.
+func checkUserDataPart(contentType, content string) error {
+	switch contentType {
+	case "text/cloud-config":
+		return checkCloudConfig(content)
+	case "text/x-shellscript":
+		if !strings.HasPrefix(content, "#!") {
+			return fmt.Errorf("shell script needs to start with a #! line")
+		}
+	}
+	return nil
+}
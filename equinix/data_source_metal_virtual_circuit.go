@@ -110,6 +110,21 @@ func dataSourceMetalVirtualCircuit() *schema.Resource {
 				Sensitive:   true,
 				Description: "The password that can be set for the VRF BGP peer",
 			},
+			"subnet_ipv6": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An IPv6 subnet from one of the IP blocks associated with the VRF used for IPv6 BGP peering",
+			},
+			"metal_ipv6": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Metal IPv6 address for the SVI (Switch Virtual Interface) of the VirtualCircuit",
+			},
+			"customer_ipv6": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Customer IPv6 address which the CSR switch will peer with",
+			},
 		},
 	}
 }
@@ -0,0 +1,116 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetworkBGP() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkBGPRead,
+		Description: "Use this data source to get details and live session state of Equinix Network Edge BGP peering configuration",
+		Schema:      createDataSourceNetworkBGPSchema(),
+	}
+}
+
+func createDataSourceNetworkBGPSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		networkBGPSchemaNames["UUID"]: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{networkBGPSchemaNames["UUID"], networkBGPSchemaNames["ConnectionUUID"]},
+			Description:  networkBGPDescriptions["UUID"],
+		},
+		networkBGPSchemaNames["ConnectionUUID"]: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{networkBGPSchemaNames["UUID"], networkBGPSchemaNames["ConnectionUUID"]},
+			Description:  networkBGPDescriptions["ConnectionUUID"],
+		},
+		networkBGPSchemaNames["ReceivedPrefixes"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: networkBGPDescriptions["ReceivedPrefixes"],
+		},
+		networkBGPSchemaNames["AdvertisedPrefixes"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: networkBGPDescriptions["AdvertisedPrefixes"],
+		},
+	}
+	for _, key := range []string{
+		"DeviceUUID", "LocalIPAddress", "LocalASN", "RemoteIPAddress", "RemoteASN",
+		"EBGPMultihop", "KeepaliveTimer", "HoldTimer", "PrefixLimit",
+		"BFDEnabled", "BFDInterval", "BFDMultiplier", "State", "ProvisioningStatus",
+	} {
+		resourceSchema := createNetworkBGPResourceSchema()[networkBGPSchemaNames[key]]
+		s[networkBGPSchemaNames[key]] = &schema.Schema{
+			Type:        resourceSchema.Type,
+			Computed:    true,
+			Description: resourceSchema.Description,
+		}
+	}
+	return s
+}
+
+func dataSourceNetworkBGPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	var diags diag.Diagnostics
+	var bgp *ne.BGPConfiguration
+	var err error
+	if v, ok := d.GetOk(networkBGPSchemaNames["UUID"]); ok {
+		bgp, err = client.GetBGPConfiguration(v.(string))
+	} else {
+		bgp, err = client.GetBGPConfigurationForConnection(d.Get(networkBGPSchemaNames["ConnectionUUID"]).(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	details, err := getNetworkBGPDetails(client, ne.StringValue(bgp.UUID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateNetworkBGPDataSource(bgp, details, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func updateNetworkBGPDataSource(bgp *ne.BGPConfiguration, details *networkBGPDetails, d *schema.ResourceData) error {
+	d.SetId(ne.StringValue(bgp.UUID))
+	attributes := map[string]interface{}{
+		networkBGPSchemaNames["UUID"]:               bgp.UUID,
+		networkBGPSchemaNames["ConnectionUUID"]:     bgp.ConnectionUUID,
+		networkBGPSchemaNames["DeviceUUID"]:         bgp.DeviceUUID,
+		networkBGPSchemaNames["LocalIPAddress"]:     bgp.LocalIPAddress,
+		networkBGPSchemaNames["LocalASN"]:           bgp.LocalASN,
+		networkBGPSchemaNames["RemoteIPAddress"]:    bgp.RemoteIPAddress,
+		networkBGPSchemaNames["RemoteASN"]:          bgp.RemoteASN,
+		networkBGPSchemaNames["EBGPMultihop"]:       details.Options.EBGPMultihop,
+		networkBGPSchemaNames["KeepaliveTimer"]:     details.Options.KeepaliveTimer,
+		networkBGPSchemaNames["HoldTimer"]:          details.Options.HoldTimer,
+		networkBGPSchemaNames["PrefixLimit"]:        details.Options.PrefixLimit,
+		networkBGPSchemaNames["BFDEnabled"]:         ne.BoolValue(details.Options.BFDEnabled),
+		networkBGPSchemaNames["BFDInterval"]:        details.Options.BFDInterval,
+		networkBGPSchemaNames["BFDMultiplier"]:      details.Options.BFDMultiplier,
+		networkBGPSchemaNames["State"]:              bgp.State,
+		networkBGPSchemaNames["ProvisioningStatus"]: bgp.ProvisioningStatus,
+		networkBGPSchemaNames["ReceivedPrefixes"]:   details.ReceivedPrefixes,
+		networkBGPSchemaNames["AdvertisedPrefixes"]: details.AdvertisedPrefixes,
+	}
+	for key, value := range attributes {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error reading %s: %s", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkBGP_updateDataSource(t *testing.T) {
+	// given
+	input := &ne.BGPConfiguration{
+		UUID:               ne.String("0cb9759d-58ab-44e6-9c10-6a3cfd18cefb"),
+		ConnectionUUID:     ne.String("6ca8d0df-c71a-4475-a835-53c2df1e6667"),
+		LocalIPAddress:     ne.String("1.1.1.1/30"),
+		RemoteIPAddress:    ne.String("1.1.1.2"),
+		AuthenticationKey:  ne.String("secret"),
+		State:              ne.String("Established"),
+		ProvisioningStatus: ne.String(ne.BGPProvisioningStatusProvisioned),
+	}
+	details := &networkBGPDetails{
+		Options: networkBGPOptions{
+			BFDEnabled: ne.Bool(true),
+		},
+		ReceivedPrefixes:   ne.Int(120),
+		AdvertisedPrefixes: ne.Int(4),
+	}
+	d := schema.TestResourceDataRaw(t, createDataSourceNetworkBGPSchema(), make(map[string]interface{}))
+	// when
+	err := updateNetworkBGPDataSource(input, details, d)
+	// then
+	assert.Nil(t, err, "Update of data source does not return error")
+	assert.Equal(t, ne.StringValue(input.UUID), d.Id(), "ID matches")
+	assert.Equal(t, "Established", d.Get(networkBGPSchemaNames["State"]), "State matches")
+	assert.Equal(t, true, d.Get(networkBGPSchemaNames["BFDEnabled"]), "BFDEnabled matches")
+	assert.Equal(t, 120, d.Get(networkBGPSchemaNames["ReceivedPrefixes"]), "ReceivedPrefixes matches")
+	assert.Equal(t, 4, d.Get(networkBGPSchemaNames["AdvertisedPrefixes"]), "AdvertisedPrefixes matches")
+}
@@ -681,117 +681,11 @@ func dataSourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m
 
 func updateDataSourceNetworkDeviceResource(primary *ne.Device, secondary *ne.Device, d *schema.ResourceData) error {
 	d.SetId(ne.StringValue(primary.UUID))
-	if err := d.Set(neDeviceSchemaNames["UUID"], primary.UUID); err != nil {
-		return fmt.Errorf("error reading UUID: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Name"], primary.Name); err != nil {
-		return fmt.Errorf("error reading Name: %s", err)
-	}
-
-	if err := d.Set(neDeviceSchemaNames["TypeCode"], primary.TypeCode); err != nil {
-		return fmt.Errorf("error reading TypeCode: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Status"], primary.Status); err != nil {
-		return fmt.Errorf("error reading Status: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["LicenseStatus"], primary.LicenseStatus); err != nil {
-		return fmt.Errorf("error reading LicenseStatus: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["MetroCode"], primary.MetroCode); err != nil {
-		return fmt.Errorf("error reading MetroCode: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["IBX"], primary.IBX); err != nil {
-		return fmt.Errorf("error reading IBX: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Region"], primary.Region); err != nil {
-		return fmt.Errorf("error reading Region: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Throughput"], primary.Throughput); err != nil {
-		return fmt.Errorf("error reading Throughput: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["ThroughputUnit"], primary.ThroughputUnit); err != nil {
-		return fmt.Errorf("error reading ThroughputUnit: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["HostName"], primary.HostName); err != nil {
-		return fmt.Errorf("error reading HostName: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["PackageCode"], primary.PackageCode); err != nil {
-		return fmt.Errorf("error reading PackageCode: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Version"], primary.Version); err != nil {
-		return fmt.Errorf("error reading Version: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["IsBYOL"], primary.IsBYOL); err != nil {
-		return fmt.Errorf("error reading IsBYOL: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["LicenseFileID"], primary.LicenseFileID); err != nil {
-		return fmt.Errorf("error reading LicenseFileID: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["ACLTemplateUUID"], primary.ACLTemplateUUID); err != nil {
-		return fmt.Errorf("error reading ACLTemplateUUID: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["SSHIPAddress"], primary.SSHIPAddress); err != nil {
-		return fmt.Errorf("error reading SSHIPAddress: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["SSHIPFqdn"], primary.SSHIPFqdn); err != nil {
-		return fmt.Errorf("error reading SSHIPFqdn: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["AccountNumber"], primary.AccountNumber); err != nil {
-		return fmt.Errorf("error reading AccountNumber: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Notifications"], primary.Notifications); err != nil {
-		return fmt.Errorf("error reading Notifications: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["PurchaseOrderNumber"], primary.PurchaseOrderNumber); err != nil {
-		return fmt.Errorf("error reading PurchaseOrderNumber: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["RedundancyType"], primary.RedundancyType); err != nil {
-		return fmt.Errorf("error reading RedundancyType: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["RedundantUUID"], primary.RedundantUUID); err != nil {
-		return fmt.Errorf("error reading RedundantUUID: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["TermLength"], primary.TermLength); err != nil {
-		return fmt.Errorf("error reading TermLength: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["AdditionalBandwidth"], primary.AdditionalBandwidth); err != nil {
-		return fmt.Errorf("error reading AdditionalBandwidth: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["OrderReference"], primary.OrderReference); err != nil {
-		return fmt.Errorf("error reading OrderReference: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["InterfaceCount"], primary.InterfaceCount); err != nil {
-		return fmt.Errorf("error reading InterfaceCount: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["CoreCount"], primary.CoreCount); err != nil {
-		return fmt.Errorf("error reading CoreCount: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["IsSelfManaged"], primary.IsSelfManaged); err != nil {
-		return fmt.Errorf("error reading IsSelfManaged: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["Interfaces"], flattenNetworkDeviceInterfaces(primary.Interfaces)); err != nil {
-		return fmt.Errorf("error reading Interfaces: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["VendorConfiguration"], primary.VendorConfiguration); err != nil {
-		return fmt.Errorf("error reading VendorConfiguration: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["UserPublicKey"], flattenNetworkDeviceUserKeys([]*ne.DeviceUserPublicKey{primary.UserPublicKey})); err != nil {
-		return fmt.Errorf("error reading VendorConfiguration: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["ASN"], primary.ASN); err != nil {
-		return fmt.Errorf("error reading ASN: %s", err)
-	}
-	if err := d.Set(neDeviceSchemaNames["ZoneCode"], primary.ZoneCode); err != nil {
-		return fmt.Errorf("error reading ZoneCode: %s", err)
-	}
 	if secondary != nil {
 		if v, ok := d.GetOk(neDeviceSchemaNames["Secondary"]); ok {
 			secondaryFromSchema := expandNetworkDeviceSecondary(v.([]interface{}))
 			secondary.LicenseFile = secondaryFromSchema.LicenseFile
 		}
-		if err := d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(secondary)); err != nil {
-			return fmt.Errorf("error reading Secondary: %s", err)
-		}
 	}
 	if primary.ClusterDetails != nil {
 		if v, ok := d.GetOk(neDeviceSchemaNames["ClusterDetails"]); ok {
@@ -801,9 +695,60 @@ func updateDataSourceNetworkDeviceResource(primary *ne.Device, secondary *ne.Dev
 			primary.ClusterDetails.Node1.LicenseFileId = clusterDetailsFromSchema.Node1.LicenseFileId
 			primary.ClusterDetails.Node1.LicenseToken = clusterDetailsFromSchema.Node1.LicenseToken
 		}
-		if err := d.Set(neDeviceSchemaNames["ClusterDetails"], flattenNetworkDeviceClusterDetails(primary.ClusterDetails)); err != nil {
-			return fmt.Errorf("error reading ClusterDetails: %s", err)
+	}
+	for key, value := range flattenDataSourceNetworkDevice(primary, secondary) {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error reading %s: %s", key, err)
 		}
 	}
 	return nil
 }
+
+// flattenDataSourceNetworkDevice returns the attributes of a device, and of
+// its secondary device when given. Attributes which can be filtered in the
+// equinix_network_devices data source have plain values.
+func flattenDataSourceNetworkDevice(primary *ne.Device, secondary *ne.Device) map[string]interface{} {
+	transformed := map[string]interface{}{
+		neDeviceSchemaNames["UUID"]:                ne.StringValue(primary.UUID),
+		neDeviceSchemaNames["Name"]:                ne.StringValue(primary.Name),
+		neDeviceSchemaNames["TypeCode"]:            ne.StringValue(primary.TypeCode),
+		neDeviceSchemaNames["Status"]:              ne.StringValue(primary.Status),
+		neDeviceSchemaNames["LicenseStatus"]:       ne.StringValue(primary.LicenseStatus),
+		neDeviceSchemaNames["MetroCode"]:           ne.StringValue(primary.MetroCode),
+		neDeviceSchemaNames["IBX"]:                 ne.StringValue(primary.IBX),
+		neDeviceSchemaNames["Region"]:              ne.StringValue(primary.Region),
+		neDeviceSchemaNames["Throughput"]:          ne.IntValue(primary.Throughput),
+		neDeviceSchemaNames["ThroughputUnit"]:      ne.StringValue(primary.ThroughputUnit),
+		neDeviceSchemaNames["HostName"]:            ne.StringValue(primary.HostName),
+		neDeviceSchemaNames["PackageCode"]:         ne.StringValue(primary.PackageCode),
+		neDeviceSchemaNames["Version"]:             ne.StringValue(primary.Version),
+		neDeviceSchemaNames["IsBYOL"]:              ne.BoolValue(primary.IsBYOL),
+		neDeviceSchemaNames["LicenseFileID"]:       ne.StringValue(primary.LicenseFileID),
+		neDeviceSchemaNames["ACLTemplateUUID"]:     ne.StringValue(primary.ACLTemplateUUID),
+		neDeviceSchemaNames["SSHIPAddress"]:        ne.StringValue(primary.SSHIPAddress),
+		neDeviceSchemaNames["SSHIPFqdn"]:           ne.StringValue(primary.SSHIPFqdn),
+		neDeviceSchemaNames["AccountNumber"]:       ne.StringValue(primary.AccountNumber),
+		neDeviceSchemaNames["Notifications"]:       schema.NewSet(schema.HashString, stringArrToIfArr(primary.Notifications)),
+		neDeviceSchemaNames["PurchaseOrderNumber"]: ne.StringValue(primary.PurchaseOrderNumber),
+		neDeviceSchemaNames["RedundancyType"]:      ne.StringValue(primary.RedundancyType),
+		neDeviceSchemaNames["RedundantUUID"]:       ne.StringValue(primary.RedundantUUID),
+		neDeviceSchemaNames["TermLength"]:          ne.IntValue(primary.TermLength),
+		neDeviceSchemaNames["AdditionalBandwidth"]: ne.IntValue(primary.AdditionalBandwidth),
+		neDeviceSchemaNames["OrderReference"]:      ne.StringValue(primary.OrderReference),
+		neDeviceSchemaNames["InterfaceCount"]:      ne.IntValue(primary.InterfaceCount),
+		neDeviceSchemaNames["CoreCount"]:           ne.IntValue(primary.CoreCount),
+		neDeviceSchemaNames["IsSelfManaged"]:       ne.BoolValue(primary.IsSelfManaged),
+		neDeviceSchemaNames["Interfaces"]:          flattenNetworkDeviceInterfaces(primary.Interfaces),
+		neDeviceSchemaNames["VendorConfiguration"]: primary.VendorConfiguration,
+		neDeviceSchemaNames["UserPublicKey"]:       flattenNetworkDeviceUserKeys([]*ne.DeviceUserPublicKey{primary.UserPublicKey}),
+		neDeviceSchemaNames["ASN"]:                 ne.IntValue(primary.ASN),
+		neDeviceSchemaNames["ZoneCode"]:            ne.StringValue(primary.ZoneCode),
+	}
+	if secondary != nil {
+		transformed[neDeviceSchemaNames["Secondary"]] = flattenNetworkDeviceSecondary(secondary)
+	}
+	if primary.ClusterDetails != nil {
+		transformed[neDeviceSchemaNames["ClusterDetails"]] = flattenNetworkDeviceClusterDetails(primary.ClusterDetails)
+	}
+	return transformed
+}
@@ -0,0 +1,85 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var networkDeviceBackupsSchemaNames = map[string]string{
+	"DeviceUUID": "device_id",
+	"Statuses":   "statuses",
+	"Backups":    "backups",
+}
+
+var networkDeviceBackupsDescriptions = map[string]string{
+	"DeviceUUID": "Unique identifier of the network device to list backups for",
+	"Statuses":   "List of backup statuses to filter by, e.g. COMPLETED",
+	"Backups":    "List of the network device backups",
+}
+
+func dataSourceNetworkDeviceBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkDeviceBackupsRead,
+		Description: "Use this data source to list configuration backups of an Equinix Network Edge device",
+		Schema: map[string]*schema.Schema{
+			networkDeviceBackupsSchemaNames["DeviceUUID"]: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  networkDeviceBackupsDescriptions["DeviceUUID"],
+			},
+			networkDeviceBackupsSchemaNames["Statuses"]: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{neDeviceBackupStatusPending, neDeviceBackupStatusCompleted, neDeviceBackupStatusFailed}, false),
+				},
+				Description: networkDeviceBackupsDescriptions["Statuses"],
+			},
+			networkDeviceBackupsSchemaNames["Backups"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: createDataSourceNetworkDeviceBackupSchema(),
+				},
+				Description: networkDeviceBackupsDescriptions["Backups"],
+			},
+		},
+	}
+}
+
+func createDataSourceNetworkDeviceBackupSchema() map[string]*schema.Schema {
+	s := createNetworkDeviceBackupSchema()
+	for _, v := range s {
+		v.Required = false
+		v.ForceNew = false
+		v.ValidateFunc = nil
+		v.Computed = true
+	}
+	return s
+}
+
+func dataSourceNetworkDeviceBackupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	var diags diag.Diagnostics
+	deviceID := d.Get(networkDeviceBackupsSchemaNames["DeviceUUID"]).(string)
+	statuses := expandListToStringList(d.Get(networkDeviceBackupsSchemaNames["Statuses"]).([]interface{}))
+	backups, err := getNetworkDeviceBackups(client, deviceID, statuses)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	transformed := make([]interface{}, len(backups))
+	for i := range backups {
+		transformed[i] = flattenNetworkDeviceBackup(backups[i])
+	}
+	d.SetId(deviceID)
+	if err := d.Set(networkDeviceBackupsSchemaNames["Backups"], transformed); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading %s: %s", networkDeviceBackupsSchemaNames["Backups"], err))
+	}
+	return diags
+}
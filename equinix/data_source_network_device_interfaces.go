@@ -0,0 +1,122 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var networkDeviceInterfacesSchemaNames = map[string]string{
+	"DeviceUUID":          "device_id",
+	"Type":                "type",
+	"Interfaces":          "interfaces",
+	"NextFreeInterfaceID": "next_free_interface_id",
+}
+
+var networkDeviceInterfacesDescriptions = map[string]string{
+	"DeviceUUID":          "Unique identifier of the network device to list interfaces for",
+	"Type":                "Interface type used to narrow down the next free interface, e.g. DATA",
+	"Interfaces":          "List of the network device interfaces",
+	"NextFreeInterfaceID": "Identifier of the available interface with the lowest identifier, not set when all interfaces are taken",
+}
+
+var networkDeviceInterfaceSchemaNames = map[string]string{
+	"AssignedConnectionUUID": "assigned_connection_id",
+}
+
+var networkDeviceInterfaceDescriptions = map[string]string{
+	"AssignedConnectionUUID": "Unique identifier of the Equinix Fabric connection assigned to the interface",
+}
+
+func dataSourceNetworkDeviceInterfaces() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkDeviceInterfacesRead,
+		Description: "Use this data source to list interfaces of an Equinix Network Edge device with their assigned connections",
+		Schema: map[string]*schema.Schema{
+			networkDeviceInterfacesSchemaNames["DeviceUUID"]: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  networkDeviceInterfacesDescriptions["DeviceUUID"],
+			},
+			networkDeviceInterfacesSchemaNames["Type"]: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  networkDeviceInterfacesDescriptions["Type"],
+			},
+			networkDeviceInterfacesSchemaNames["Interfaces"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: createDataSourceNetworkDeviceInterfacesItemSchema(),
+				},
+				Description: networkDeviceInterfacesDescriptions["Interfaces"],
+			},
+			networkDeviceInterfacesSchemaNames["NextFreeInterfaceID"]: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: networkDeviceInterfacesDescriptions["NextFreeInterfaceID"],
+			},
+		},
+	}
+}
+
+func createDataSourceNetworkDeviceInterfacesItemSchema() map[string]*schema.Schema {
+	s := createNetworkDeviceInterfaceSchema()
+	s[networkDeviceInterfaceSchemaNames["AssignedConnectionUUID"]] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: networkDeviceInterfaceDescriptions["AssignedConnectionUUID"],
+	}
+	return s
+}
+
+func dataSourceNetworkDeviceInterfacesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	var diags diag.Diagnostics
+	deviceID := d.Get(networkDeviceInterfacesSchemaNames["DeviceUUID"]).(string)
+	interfaces, err := getNetworkDeviceInterfaces(client, deviceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(deviceID)
+	if err := updateNetworkDeviceInterfacesDataSource(interfaces, d.Get(networkDeviceInterfacesSchemaNames["Type"]).(string), d); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func updateNetworkDeviceInterfacesDataSource(interfaces []networkDeviceInterface, interfaceType string, d *schema.ResourceData) error {
+	if err := d.Set(networkDeviceInterfacesSchemaNames["Interfaces"], flattenNetworkDeviceInterfacesWithConnections(interfaces)); err != nil {
+		return fmt.Errorf("error reading %s: %s", networkDeviceInterfacesSchemaNames["Interfaces"], err)
+	}
+	if next := findNextFreeNetworkDeviceInterface(interfaces, interfaceType); next != nil {
+		if err := d.Set(networkDeviceInterfacesSchemaNames["NextFreeInterfaceID"], next.ID); err != nil {
+			return fmt.Errorf("error reading %s: %s", networkDeviceInterfacesSchemaNames["NextFreeInterfaceID"], err)
+		}
+	}
+	return nil
+}
+
+func flattenNetworkDeviceInterfacesWithConnections(interfaces []networkDeviceInterface) interface{} {
+	transformed := make([]interface{}, len(interfaces))
+	for i := range interfaces {
+		transformed[i] = map[string]interface{}{
+			neDeviceInterfaceSchemaNames["ID"]:                          ne.IntValue(interfaces[i].ID),
+			neDeviceInterfaceSchemaNames["Name"]:                        ne.StringValue(interfaces[i].Name),
+			neDeviceInterfaceSchemaNames["Status"]:                      ne.StringValue(interfaces[i].Status),
+			neDeviceInterfaceSchemaNames["OperationalStatus"]:           ne.StringValue(interfaces[i].OperationalStatus),
+			neDeviceInterfaceSchemaNames["MACAddress"]:                  ne.StringValue(interfaces[i].MACAddress),
+			neDeviceInterfaceSchemaNames["IPAddress"]:                   ne.StringValue(interfaces[i].IPAddress),
+			neDeviceInterfaceSchemaNames["AssignedType"]:                ne.StringValue(interfaces[i].AssignedType),
+			neDeviceInterfaceSchemaNames["Type"]:                        ne.StringValue(interfaces[i].Type),
+			networkDeviceInterfaceSchemaNames["AssignedConnectionUUID"]: ne.StringValue(interfaces[i].AssignedConnectionUUID),
+		}
+	}
+	return transformed
+}
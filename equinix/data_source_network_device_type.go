@@ -12,21 +12,37 @@ import (
 )
 
 var networkDeviceTypeSchemaNames = map[string]string{
-	"Name":        "name",
-	"Code":        "code",
-	"Description": "description",
-	"Vendor":      "vendor",
-	"Category":    "category",
-	"MetroCodes":  "metro_codes",
+	"Name":                "name",
+	"Code":                "code",
+	"Description":         "description",
+	"Vendor":              "vendor",
+	"Category":            "category",
+	"MetroCodes":          "metro_codes",
+	"VendorConfiguration": "vendor_configuration",
 }
 
 var networkDeviceTypeDescriptions = map[string]string{
-	"Name":        "Device type name",
-	"Code":        "Device type short code, unique identifier of a network device type",
-	"Description": "Device type textual description",
-	"Vendor":      "Device type vendor i.e. Cisco, Juniper Networks, VERSA Networks",
-	"Category":    "Device type category, one of: Router, Firewall, SDWAN",
-	"MetroCodes":  "List of metro codes where device type has to be available",
+	"Name":                "Device type name",
+	"Code":                "Device type short code, unique identifier of a network device type",
+	"Description":         "Device type textual description",
+	"Vendor":              "Device type vendor i.e. Cisco, Juniper Networks, VERSA Networks",
+	"Category":            "Device type category, one of: Router, Firewall, SDWAN",
+	"MetroCodes":          "List of metro codes where device type has to be available",
+	"VendorConfiguration": "List of vendor configuration keys known by the provider for the device type, which are not returned by the Network Edge API. Keys which are not listed are rejected. Empty when vendor configuration of the device type is not validated",
+}
+
+var networkDeviceTypeVendorConfigSchemaNames = map[string]string{
+	"Key":       "key",
+	"Required":  "required",
+	"Sensitive": "sensitive",
+	"Format":    "format",
+}
+
+var networkDeviceTypeVendorConfigDescriptions = map[string]string{
+	"Key":       "Vendor configuration key",
+	"Required":  "Indicates if the key is required by the device type",
+	"Sensitive": "Indicates if the value of the key is sensitive",
+	"Format":    "Expected format of the value, one of: IPV4, SITE_ID. Empty when the format is not checked",
 }
 
 func dataSourceNetworkDeviceType() *schema.Resource {
@@ -76,6 +92,35 @@ func dataSourceNetworkDeviceType() *schema.Resource {
 				},
 				Description: networkDeviceTypeDescriptions["MetroCodes"],
 			},
+			networkDeviceTypeSchemaNames["VendorConfiguration"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						networkDeviceTypeVendorConfigSchemaNames["Key"]: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: networkDeviceTypeVendorConfigDescriptions["Key"],
+						},
+						networkDeviceTypeVendorConfigSchemaNames["Required"]: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: networkDeviceTypeVendorConfigDescriptions["Required"],
+						},
+						networkDeviceTypeVendorConfigSchemaNames["Sensitive"]: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: networkDeviceTypeVendorConfigDescriptions["Sensitive"],
+						},
+						networkDeviceTypeVendorConfigSchemaNames["Format"]: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: networkDeviceTypeVendorConfigDescriptions["Format"],
+						},
+					},
+				},
+				Description: networkDeviceTypeDescriptions["VendorConfiguration"],
+			},
 		},
 	}
 }
@@ -139,5 +184,21 @@ func updateNetworkDeviceTypeResource(deviceType ne.DeviceType, d *schema.Resourc
 	if err := d.Set(networkDeviceTypeSchemaNames["MetroCodes"], deviceType.MetroCodes); err != nil {
 		return fmt.Errorf("error reading MetroCodes: %s", err)
 	}
+	if err := d.Set(networkDeviceTypeSchemaNames["VendorConfiguration"], flattenNetworkDeviceTypeVendorConfig(getNetworkDeviceVendorConfigRequirements(ne.StringValue(deviceType.Code)))); err != nil {
+		return fmt.Errorf("error reading VendorConfiguration: %s", err)
+	}
 	return nil
 }
+
+func flattenNetworkDeviceTypeVendorConfig(requirements []networkDeviceVendorConfigRequirement) interface{} {
+	transformed := make([]interface{}, len(requirements))
+	for i := range requirements {
+		transformed[i] = map[string]interface{}{
+			networkDeviceTypeVendorConfigSchemaNames["Key"]:       requirements[i].Key,
+			networkDeviceTypeVendorConfigSchemaNames["Required"]:  requirements[i].Required,
+			networkDeviceTypeVendorConfigSchemaNames["Sensitive"]: requirements[i].Sensitive,
+			networkDeviceTypeVendorConfigSchemaNames["Format"]:    requirements[i].Format,
+		}
+	}
+	return transformed
+}
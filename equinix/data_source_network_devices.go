@@ -0,0 +1,86 @@
+package equinix
+
+import (
+	"fmt"
+
+	"github.com/equinix/ne-go"
+	"github.com/equinix/terraform-provider-equinix/equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var networkDevicesSchemaNames = map[string]string{
+	"Devices":   "devices",
+	"ClusterID": "cluster_id",
+}
+
+var networkDevicesDescriptions = map[string]string{
+	"Devices":         "List of Network Edge devices that match specified filters",
+	"ClusterID":       "The ID of the cluster the device is a node of, empty for devices which are not clustered",
+	"ValidStatusList": "Comma Separated List of states of devices to fetch. If not specified, devices in all states are fetched",
+}
+
+func dataSourceNetworkDevices() *schema.Resource {
+	recordSchema := createDataSourceNetworkDeviceSchema()
+	delete(recordSchema, neDeviceSchemaNames["ValidStatusList"])
+	for _, v := range recordSchema {
+		v.Default = nil
+		v.ExactlyOneOf = nil
+		v.ConflictsWith = nil
+		v.ValidateFunc = nil
+		v.DiffSuppressFunc = nil
+	}
+	recordSchema[networkDevicesSchemaNames["ClusterID"]] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: networkDevicesDescriptions["ClusterID"],
+	}
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               recordSchema,
+		ResultAttributeName:        networkDevicesSchemaNames["Devices"],
+		ResultAttributeDescription: networkDevicesDescriptions["Devices"],
+		FlattenRecord:              flattenNetworkDevicesRecord,
+		GetRecords:                 getNetworkDevicesRecords,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			neDeviceSchemaNames["ValidStatusList"]: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  networkDevicesDescriptions["ValidStatusList"],
+				ValidateFunc: stringIsValidDeviceStateList,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+// getNetworkDevicesRecords fetches the devices page by page, with the page size
+// of the response_max_page_size provider setting.
+func getNetworkDevicesRecords(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*Config).ne
+	statuses, err := getNeDeviceStatusList(extra[neDeviceSchemaNames["ValidStatusList"]].(string))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get network device status list due to '%v'", err)
+	}
+	devices, err := client.GetDevices(*statuses)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]interface{}, len(devices))
+	for i := range devices {
+		records[i] = devices[i]
+	}
+	return records, nil
+}
+
+// flattenNetworkDevicesRecord flattens a single device. Both devices of an HA
+// pair are separate records, linked with the redundant_id attribute.
+func flattenNetworkDevicesRecord(record, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	device, ok := record.(ne.Device)
+	if !ok {
+		return nil, fmt.Errorf("expected device to be of type ne.Device, got %T", record)
+	}
+	transformed := flattenDataSourceNetworkDevice(&device, nil)
+	transformed[networkDevicesSchemaNames["ClusterID"]] = ""
+	if device.ClusterDetails != nil {
+		transformed[networkDevicesSchemaNames["ClusterID"]] = ne.StringValue(device.ClusterDetails.ClusterId)
+	}
+	return transformed, nil
+}
@@ -0,0 +1,61 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDevices_flattenRecord(t *testing.T) {
+	// given
+	clustered := ne.Device{
+		UUID:          ne.String("8895983f-00f9-42f1-a387-85248f2aab49"),
+		Name:          ne.String("fw-node0"),
+		TypeCode:      ne.String("PA-VM"),
+		MetroCode:     ne.String("SV"),
+		Status:        ne.String(ne.DeviceStateProvisioned),
+		Throughput:    ne.Int(1),
+		Notifications: []string{"ops@example.com"},
+		ClusterDetails: &ne.ClusterDetails{
+			ClusterId:   ne.String("0b4fa7a3-3f43-4f16-bc3b-c4a16fb15da8"),
+			ClusterName: ne.String("fw"),
+			NumOfNodes:  ne.Int(2),
+			Node0:       &ne.ClusterNodeDetail{UUID: ne.String("8895983f-00f9-42f1-a387-85248f2aab49")},
+			Node1:       &ne.ClusterNodeDetail{UUID: ne.String("d1a3c6e7-6bce-4ed4-a6f2-96e5cfb6f2a0")},
+		},
+	}
+	standalone := ne.Device{
+		UUID:           ne.String("d4e1a4b2-4dd9-4a7e-8ffd-1a3e4d2a3ad1"),
+		RedundancyType: ne.String("PRIMARY"),
+	}
+	// when
+	clusteredRecord, clusteredErr := flattenNetworkDevicesRecord(clustered, nil, nil)
+	standaloneRecord, standaloneErr := flattenNetworkDevicesRecord(standalone, nil, nil)
+	_, invalidErr := flattenNetworkDevicesRecord("device", nil, nil)
+	// then
+	assert.Nil(t, clusteredErr, "Flatten of clustered device does not return error")
+	assert.Nil(t, standaloneErr, "Flatten of standalone device does not return error")
+	assert.Error(t, invalidErr, "Flatten of a record which is not a device returns error")
+	assert.Equal(t, "PA-VM", clusteredRecord[neDeviceSchemaNames["TypeCode"]], "Type code is a plain value")
+	assert.Equal(t, 1, clusteredRecord[neDeviceSchemaNames["Throughput"]], "Throughput is a plain value")
+	assert.True(t, clusteredRecord[neDeviceSchemaNames["Notifications"]].(*schema.Set).Contains("ops@example.com"), "Notifications are a set")
+	assert.Equal(t, "0b4fa7a3-3f43-4f16-bc3b-c4a16fb15da8", clusteredRecord[networkDevicesSchemaNames["ClusterID"]], "Cluster ID matches")
+	assert.Equal(t, "", standaloneRecord[networkDevicesSchemaNames["ClusterID"]], "Cluster ID is empty for standalone device")
+	assert.Equal(t, "PRIMARY", standaloneRecord[neDeviceSchemaNames["RedundancyType"]], "Redundancy type matches")
+	assert.NotContains(t, standaloneRecord, neDeviceSchemaNames["ClusterDetails"], "Cluster details are not set for standalone device")
+}
+
+func TestNetworkDevices_schema(t *testing.T) {
+	// given
+	resource := dataSourceNetworkDevices()
+	// when
+	err := resource.InternalValidate(nil, false)
+	devices := resource.Schema[networkDevicesSchemaNames["Devices"]].Elem.(*schema.Resource).Schema
+	// then
+	assert.Nil(t, err, "Data source schema is valid")
+	assert.NotContains(t, devices, neDeviceSchemaNames["ValidStatusList"], "Status list is not a device attribute")
+	assert.Contains(t, devices, networkDevicesSchemaNames["ClusterID"], "Cluster ID is a device attribute")
+	assert.Contains(t, resource.Schema, neDeviceSchemaNames["ValidStatusList"], "Status list is a query argument")
+}
@@ -29,6 +29,10 @@ func friendlyError(err error) error {
 }
 
 func friendlyErrorForMetalGo(err error, resp *http.Response) error {
+	// errors raised before a response was received can not be improved
+	if resp == nil {
+		return err
+	}
 	errors := Errors([]string{err.Error()})
 	return convertToFriendlyError(errors, resp)
 }
@@ -183,6 +183,109 @@ func getWaitForDeviceLock(deviceID string) *sync.WaitGroup {
 	return wg
 }
 
+// plannedDeviceCapacity keeps the devices planned for creation with
+// capacity_check set, by metro and plan, and the capacity warnings of these
+// devices. It is kept in the provider Config, so it is scoped to one
+// configured provider.
+type plannedDeviceCapacity struct {
+	mu       sync.Mutex
+	devices  map[string]map[string]bool
+	warnings map[string]string
+}
+
+// count records the device planned for creation and returns the number of
+// devices planned so far for the same metro and plan. Devices are recorded by
+// key, so a device diffed more than once is counted once.
+func (p *plannedDeviceCapacity) count(metro, plan, deviceKey string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.devices == nil {
+		p.devices = map[string]map[string]bool{}
+	}
+	capacityKey := fmt.Sprintf("%s/%s", metro, plan)
+	devices, ok := p.devices[capacityKey]
+	if !ok {
+		devices = map[string]bool{}
+		p.devices[capacityKey] = devices
+	}
+	devices[deviceKey] = true
+	return len(devices)
+}
+
+// setWarning records the capacity warning of the device, an empty warning
+// clears it.
+func (p *plannedDeviceCapacity) setWarning(deviceKey, warning string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.warnings == nil {
+		p.warnings = map[string]string{}
+	}
+	if warning == "" {
+		delete(p.warnings, deviceKey)
+		return
+	}
+	p.warnings[deviceKey] = warning
+}
+
+func (p *plannedDeviceCapacity) warning(deviceKey string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.warnings[deviceKey]
+}
+
+func plannedDeviceKey(projectID, hostname string) string {
+	return fmt.Sprintf("%s/%s", projectID, hostname)
+}
+
+// checkPlannedDeviceCapacity is a CustomizeDiff function which, if the device
+// opts in with capacity_check, verifies that the metro has capacity for all
+// the devices of the same plan that are planned for creation. With
+// capacity_check = "error" insufficient capacity fails the plan, with "warn"
+// it is reported as a warning when the device is created, as CustomizeDiff
+// can't return warnings.
+func checkPlannedDeviceCapacity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	mode := d.Get("capacity_check").(string)
+	if mode == "" || d.Id() != "" {
+		return nil
+	}
+	// devices deployed to a hardware reservation don't need metro capacity
+	if _, ok := d.GetOk("hardware_reservation_id"); ok {
+		return nil
+	}
+	metro := d.Get("metro").(string)
+	plan := d.Get("plan").(string)
+	if !d.NewValueKnown("metro") || !d.NewValueKnown("plan") || metro == "" || plan == "" {
+		return nil
+	}
+
+	// a device which can't be told apart from the others yet is not counted,
+	// the check is deferred to the diff done on apply, when it is known
+	hostname := d.Get("hostname").(string)
+	if !d.NewValueKnown("hostname") || !d.NewValueKnown("project_id") || hostname == "" {
+		log.Printf("[DEBUG] Deferring capacity check of device planned in metro %s, hostname or project is not known yet", metro)
+		return nil
+	}
+	planned := &meta.(*Config).plannedDevices
+	deviceKey := plannedDeviceKey(d.Get("project_id").(string), hostname)
+	quantity := planned.count(metro, plan, deviceKey)
+
+	available, err := checkMetroCapacity(meta.(*Config).metal, metro, plan, quantity)
+	if err != nil {
+		return err
+	}
+	warning := ""
+	if !available {
+		msg := fmt.Sprintf("not enough capacity in metro %s for %d device(s) of plan %s", metro, quantity, plan)
+		if mode == "error" {
+			return errors.New(msg)
+		}
+		log.Printf("[WARN] %s", msg)
+		warning = msg
+	}
+	planned.setWarning(deviceKey, warning)
+	return nil
+}
+
 func waitForDeviceAttribute(ctx context.Context, d *schema.ResourceData, stateConf *retry.StateChangeConf) (string, error) {
 	wg := getWaitForDeviceLock(d.Id())
 	wg.Wait()
@@ -0,0 +1,466 @@
+package equinix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+var (
+	storageRaidLevels = []string{"0", "1", "5", "6", "10"}
+	// minimal number of devices of a RAID array by level
+	storageRaidMinDevices = map[string]int{"0": 2, "1": 2, "5": 3, "6": 4, "10": 4}
+	matchStorageSize      = regexp.MustCompile(`(?i)^([0-9.]+)\s*([KMGTP]?)(I?B)?$`)
+)
+
+func storageLayoutSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Description:   "Custom partitioning and RAID layout of the device disks. Only usable on reserved hardware. It is an alternative to the storage JSON",
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"storage"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"disk": {
+					Type:        schema.TypeList,
+					Description: "Disk to partition",
+					Optional:    true,
+					ForceNew:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"device": {
+								Type:         schema.TypeString,
+								Description:  "Device path of the disk, e.g. /dev/sda",
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/dev/`), "must be a device path starting with /dev/"),
+							},
+							"wipe_table": {
+								Type:        schema.TypeBool,
+								Description: "Whether to wipe the partition table of the disk",
+								Optional:    true,
+								ForceNew:    true,
+								Default:     true,
+							},
+							"partition": {
+								Type:        schema.TypeList,
+								Description: "Partition of the disk",
+								Optional:    true,
+								ForceNew:    true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"label": {
+											Type:        schema.TypeString,
+											Description: "Label of the partition, e.g. BIOS, SWAP or ROOT",
+											Required:    true,
+											ForceNew:    true,
+										},
+										"number": {
+											Type:         schema.TypeInt,
+											Description:  "Number of the partition",
+											Required:     true,
+											ForceNew:     true,
+											ValidateFunc: validation.IntAtLeast(1),
+										},
+										"size": {
+											Type:             schema.TypeString,
+											Description:      "Size of the partition, e.g. 512M or 4G. 0 uses the rest of the disk",
+											Required:         true,
+											ForceNew:         true,
+											DiffSuppressFunc: suppressStorageSizeDiff,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"raid": {
+					Type:        schema.TypeList,
+					Description: "Software RAID array",
+					Optional:    true,
+					ForceNew:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Device path of the array, e.g. /dev/md/ROOT",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"level": {
+								Type:         schema.TypeString,
+								Description:  "RAID level, one of " + strings.Join(storageRaidLevels, ", "),
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringInSlice(storageRaidLevels, false),
+							},
+							"devices": {
+								Type:        schema.TypeList,
+								Description: "Partitions or disks of the array, e.g. /dev/sda2",
+								Required:    true,
+								ForceNew:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"filesystem": {
+					Type:        schema.TypeList,
+					Description: "Filesystem to create on a partition, disk or RAID array",
+					Optional:    true,
+					ForceNew:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"device": {
+								Type:        schema.TypeString,
+								Description: "Partition, disk or RAID array to create the filesystem on",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"format": {
+								Type:        schema.TypeString,
+								Description: "Filesystem format, e.g. ext4, xfs, vfat or swap",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"point": {
+								Type:             schema.TypeString,
+								Description:      "Mount point of the filesystem, e.g. /. Swap is not mounted",
+								Optional:         true,
+								ForceNew:         true,
+								DiffSuppressFunc: suppressStorageMountPointDiff,
+							},
+							"options": {
+								Type:        schema.TypeList,
+								Description: "Options passed to mkfs when the filesystem is created",
+								Optional:    true,
+								ForceNew:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseStorageSize returns the size in bytes. Sizes without a unit are
+// 512 byte sectors and sizes without a B suffix are in binary units as in the
+// CPR format, sizes with a B suffix are in decimal units as in the plan drive
+// inventory.
+func parseStorageSize(size string) (float64, error) {
+	m := matchStorageSize.FindStringSubmatch(strings.TrimSpace(size))
+	if m == nil {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %s", size, err)
+	}
+	if m[2] == "" && m[3] == "" {
+		return v * 512, nil
+	}
+	base := 1024.0
+	if strings.EqualFold(m[3], "B") {
+		base = 1000.0
+	}
+	for _, u := range "KMGTP" {
+		if m[2] == "" {
+			break
+		}
+		v *= base
+		if strings.EqualFold(m[2], string(u)) {
+			break
+		}
+	}
+	return v, nil
+}
+
+func suppressStorageSizeDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, oerr := parseStorageSize(old)
+	n, nerr := parseStorageSize(new)
+	return oerr == nil && nerr == nil && o == n
+}
+
+// swap is reported with the none mount point
+func suppressStorageMountPointDiff(k, old, new string, d *schema.ResourceData) bool {
+	return (old == "none" && new == "") || (old == "" && new == "none")
+}
+
+// storagePartitionDevice returns the device path of the partition, the
+// partitions of NVMe disks are separated with a p.
+func storagePartitionDevice(disk string, number int) string {
+	if len(disk) > 0 && disk[len(disk)-1] >= '0' && disk[len(disk)-1] <= '9' {
+		return fmt.Sprintf("%sp%d", disk, number)
+	}
+	return fmt.Sprintf("%s%d", disk, number)
+}
+
+// expandStorageLayout converts the storage_layout block to the CPR format of
+// the device create request.
+func expandStorageLayout(layout []interface{}) (*packngo.CPR, error) {
+	if len(layout) == 0 || layout[0] == nil {
+		return nil, nil
+	}
+	l := layout[0].(map[string]interface{})
+
+	disks := []interface{}{}
+	for _, rawDisk := range l["disk"].([]interface{}) {
+		disk := rawDisk.(map[string]interface{})
+		partitions := []interface{}{}
+		for _, rawPart := range disk["partition"].([]interface{}) {
+			part := rawPart.(map[string]interface{})
+			partitions = append(partitions, map[string]interface{}{
+				"label":  part["label"],
+				"number": part["number"],
+				"size":   part["size"],
+			})
+		}
+		disks = append(disks, map[string]interface{}{
+			"device":     disk["device"],
+			"wipeTable":  disk["wipe_table"],
+			"partitions": partitions,
+		})
+	}
+
+	raids := []interface{}{}
+	for _, rawRaid := range l["raid"].([]interface{}) {
+		raid := rawRaid.(map[string]interface{})
+		raids = append(raids, map[string]interface{}{
+			"name":    raid["name"],
+			"level":   raid["level"],
+			"devices": raid["devices"],
+		})
+	}
+
+	filesystems := []interface{}{}
+	for _, rawFS := range l["filesystem"].([]interface{}) {
+		fs := rawFS.(map[string]interface{})
+		point := fs["point"].(string)
+		if point == "" && fs["format"].(string) == "swap" {
+			point = "none"
+		}
+		filesystems = append(filesystems, map[string]interface{}{
+			"mount": map[string]interface{}{
+				"device": fs["device"],
+				"format": fs["format"],
+				"point":  point,
+				"create": map[string]interface{}{
+					"options": fs["options"],
+				},
+			},
+		})
+	}
+
+	cprJSON, err := json.Marshal(map[string]interface{}{
+		"disks":       disks,
+		"raid":        raids,
+		"filesystems": filesystems,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var cpr packngo.CPR
+	if err := json.Unmarshal(cprJSON, &cpr); err != nil {
+		return nil, fmt.Errorf("error converting storage_layout: %s", err)
+	}
+	return &cpr, nil
+}
+
+// flattenStorageLayout converts the device storage reported by the API to the
+// storage_layout block.
+func flattenStorageLayout(storage *metalv1.Storage) []interface{} {
+	if storage == nil {
+		return nil
+	}
+
+	disks := []interface{}{}
+	for _, disk := range storage.GetDisks() {
+		partitions := []interface{}{}
+		for _, part := range disk.GetPartitions() {
+			partitions = append(partitions, map[string]interface{}{
+				"label":  part.GetLabel(),
+				"number": int(part.GetNumber()),
+				"size":   part.GetSize(),
+			})
+		}
+		disks = append(disks, map[string]interface{}{
+			"device":     disk.GetDevice(),
+			"wipe_table": disk.GetWipeTable(),
+			"partition":  partitions,
+		})
+	}
+
+	raids := []interface{}{}
+	for _, raid := range storage.GetRaid() {
+		raids = append(raids, map[string]interface{}{
+			"name":    raid.GetName(),
+			"level":   raid.GetLevel(),
+			"devices": raid.GetDevices(),
+		})
+	}
+
+	filesystems := []interface{}{}
+	for _, fs := range storage.GetFilesystems() {
+		mount := fs.GetMount()
+		filesystems = append(filesystems, map[string]interface{}{
+			"device":  mount.GetDevice(),
+			"format":  mount.GetFormat(),
+			"point":   mount.GetPoint(),
+			"options": getStorageMountOptions(mount),
+		})
+	}
+
+	return []interface{}{map[string]interface{}{
+		"disk":       disks,
+		"raid":       raids,
+		"filesystem": filesystems,
+	}}
+}
+
+// getStorageMountOptions returns the mkfs options of a filesystem. The options
+// are sent as mount.create.options, which metal-go doesn't model, so they are
+// read from the additional properties of the mount.
+func getStorageMountOptions(mount metalv1.Mount) []string {
+	create, ok := mount.AdditionalProperties["create"].(map[string]interface{})
+	if !ok {
+		return mount.GetOptions()
+	}
+	rawOptions, ok := create["options"].([]interface{})
+	if !ok {
+		return mount.GetOptions()
+	}
+	options := make([]string, 0, len(rawOptions))
+	for _, o := range rawOptions {
+		if option, ok := o.(string); ok {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// checkStorageLayout checks that the devices referenced in the layout exist,
+// that the RAID arrays have enough devices and, if the drives of the plan are
+// known, that the disks fit in them.
+func checkStorageLayout(layout []interface{}, drives []*packngo.Drives) error {
+	if len(layout) == 0 || layout[0] == nil {
+		return nil
+	}
+	l := layout[0].(map[string]interface{})
+
+	blockDevices := map[string]bool{}
+	var largestDrive float64
+	driveCount := 0
+	for _, drive := range drives {
+		driveCount += drive.Count
+		if size, err := parseStorageSize(drive.Size); err == nil && size > largestDrive {
+			largestDrive = size
+		}
+	}
+
+	rawDisks := l["disk"].([]interface{})
+	if len(drives) > 0 && len(rawDisks) > driveCount {
+		return fmt.Errorf("storage_layout has %d disks, the plan has %d drives", len(rawDisks), driveCount)
+	}
+	for _, rawDisk := range rawDisks {
+		disk := rawDisk.(map[string]interface{})
+		device := disk["device"].(string)
+		if blockDevices[device] {
+			return fmt.Errorf("disk %s is in storage_layout more than once", device)
+		}
+		blockDevices[device] = true
+
+		var sized float64
+		rawParts := disk["partition"].([]interface{})
+		for i, rawPart := range rawParts {
+			part := rawPart.(map[string]interface{})
+			partDevice := storagePartitionDevice(device, part["number"].(int))
+			if blockDevices[partDevice] {
+				return fmt.Errorf("partition number %d is used more than once on disk %s", part["number"].(int), device)
+			}
+			blockDevices[partDevice] = true
+
+			size, err := parseStorageSize(part["size"].(string))
+			if err != nil {
+				return fmt.Errorf("partition %s: %s", partDevice, err)
+			}
+			if size == 0 && i != len(rawParts)-1 {
+				return fmt.Errorf("partition %s: only the last partition of a disk can use the rest of the disk", partDevice)
+			}
+			sized += size
+		}
+		if largestDrive > 0 && sized > largestDrive {
+			return fmt.Errorf("partitions of disk %s don't fit in the largest drive of the plan", device)
+		}
+	}
+
+	for _, rawRaid := range l["raid"].([]interface{}) {
+		raid := rawRaid.(map[string]interface{})
+		name := raid["name"].(string)
+		devices := convertStringArr(raid["devices"].([]interface{}))
+		level := raid["level"].(string)
+		if len(devices) < storageRaidMinDevices[level] {
+			return fmt.Errorf("RAID %s level %s needs at least %d devices", name, level, storageRaidMinDevices[level])
+		}
+		for _, dev := range devices {
+			if !blockDevices[dev] {
+				return fmt.Errorf("RAID %s device %s is not a disk or partition of the storage_layout", name, dev)
+			}
+		}
+		blockDevices[name] = true
+	}
+
+	mountPoints := map[string]bool{}
+	for _, rawFS := range l["filesystem"].([]interface{}) {
+		fs := rawFS.(map[string]interface{})
+		device := fs["device"].(string)
+		if !blockDevices[device] {
+			return fmt.Errorf("filesystem device %s is not a disk, partition or RAID array of the storage_layout", device)
+		}
+		point := fs["point"].(string)
+		if point == "" || point == "none" {
+			continue
+		}
+		if mountPoints[point] {
+			return fmt.Errorf("mount point %s is used more than once", point)
+		}
+		mountPoints[point] = true
+	}
+	return nil
+}
+
+// checkPlannedStorageLayout is a CustomizeDiff function which checks the
+// storage_layout of a new device against the drives of its plan.
+func checkPlannedStorageLayout(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	layout := d.Get("storage_layout").([]interface{})
+	if d.Id() != "" || len(layout) == 0 || !d.NewValueKnown("storage_layout") {
+		return nil
+	}
+
+	var drives []*packngo.Drives
+	if plan := d.Get("plan").(string); d.NewValueKnown("plan") && plan != "" {
+		plans, _, err := meta.(*Config).metal.Plans.List(nil)
+		if err != nil {
+			return friendlyError(err)
+		}
+		for _, p := range plans {
+			if p.Slug == plan && p.Specs != nil {
+				drives = p.Specs.Drives
+				break
+			}
+		}
+	}
+	return checkStorageLayout(layout, drives)
+}
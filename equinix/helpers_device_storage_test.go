@@ -0,0 +1,166 @@
+package equinix
+
+import (
+	"encoding/json"
+	"testing"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStorageLayout() []interface{} {
+	disk := func(device string) map[string]interface{} {
+		return map[string]interface{}{
+			"device":     device,
+			"wipe_table": true,
+			"partition": []interface{}{
+				map[string]interface{}{"label": "BIOS", "number": 1, "size": "4096"},
+				map[string]interface{}{"label": "SWAP", "number": 2, "size": "3993600"},
+				map[string]interface{}{"label": "ROOT", "number": 3, "size": "0"},
+			},
+		}
+	}
+	return []interface{}{map[string]interface{}{
+		"disk": []interface{}{disk("/dev/sda"), disk("/dev/nvme0n1")},
+		"raid": []interface{}{
+			map[string]interface{}{
+				"name":    "/dev/md/ROOT",
+				"level":   "1",
+				"devices": []interface{}{"/dev/sda3", "/dev/nvme0n1p3"},
+			},
+		},
+		"filesystem": []interface{}{
+			map[string]interface{}{
+				"device":  "/dev/md/ROOT",
+				"format":  "ext4",
+				"point":   "/",
+				"options": []interface{}{"-L", "ROOT"},
+			},
+			map[string]interface{}{
+				"device":  "/dev/sda2",
+				"format":  "swap",
+				"point":   "",
+				"options": []interface{}{"-L", "SWAP"},
+			},
+		},
+	}}
+}
+
+func Test_parseStorageSize(t *testing.T) {
+	// given
+	tests := map[string]float64{
+		"0":      0,
+		"4096":   4096 * 512,
+		"512M":   512 * 1024 * 1024,
+		"4G":     4 * 1024 * 1024 * 1024,
+		"480GB":  480e9,
+		"3.8TB":  3.8e12,
+		"1.5 TB": 1.5e12,
+	}
+	for size, expected := range tests {
+		// when
+		bytes, err := parseStorageSize(size)
+		// then
+		assert.NoError(t, err, size)
+		assert.InDelta(t, expected, bytes, 1, size)
+	}
+	_, err := parseStorageSize("big")
+	assert.Error(t, err)
+}
+
+func Test_checkStorageLayout(t *testing.T) {
+	// given
+	drives := []*packngo.Drives{{Count: 2, Size: "480GB", Type: "SSD"}}
+	oneDrive := []*packngo.Drives{{Count: 1, Size: "480GB", Type: "SSD"}}
+	smallDrives := []*packngo.Drives{{Count: 2, Size: "1GB", Type: "SSD"}}
+
+	badRaid := testStorageLayout()
+	badRaid[0].(map[string]interface{})["raid"].([]interface{})[0].(map[string]interface{})["devices"] = []interface{}{"/dev/sda3", "/dev/sdb3"}
+	badFS := testStorageLayout()
+	badFS[0].(map[string]interface{})["filesystem"].([]interface{})[0].(map[string]interface{})["device"] = "/dev/md/DATA"
+	// when
+	validErr := checkStorageLayout(testStorageLayout(), drives)
+	noPlanErr := checkStorageLayout(testStorageLayout(), nil)
+	tooManyDisksErr := checkStorageLayout(testStorageLayout(), oneDrive)
+	tooLargeErr := checkStorageLayout(testStorageLayout(), smallDrives)
+	badRaidErr := checkStorageLayout(badRaid, drives)
+	badFSErr := checkStorageLayout(badFS, drives)
+	// then
+	assert.NoError(t, validErr)
+	assert.NoError(t, noPlanErr)
+	assert.ErrorContains(t, tooManyDisksErr, "2 disks")
+	assert.ErrorContains(t, tooLargeErr, "don't fit")
+	assert.ErrorContains(t, badRaidErr, "/dev/sdb3")
+	assert.ErrorContains(t, badFSErr, "/dev/md/DATA")
+}
+
+func Test_expandStorageLayout(t *testing.T) {
+	// given
+	layout := testStorageLayout()
+	// when
+	cpr, err := expandStorageLayout(layout)
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, cpr.Disks, 2)
+	assert.True(t, cpr.Disks[0].WipeTable)
+	assert.Equal(t, 3, cpr.Disks[1].Partitions[2].Number)
+	assert.Equal(t, "1", cpr.Raid[0].Level)
+	assert.Equal(t, []string{"/dev/sda3", "/dev/nvme0n1p3"}, cpr.Raid[0].Devices)
+	assert.Equal(t, "/", cpr.Filesystems[0].Mount.Point)
+	assert.Equal(t, "none", cpr.Filesystems[1].Mount.Point)
+	assert.Equal(t, []string{"-L", "SWAP"}, cpr.Filesystems[1].Mount.Create.Options)
+}
+
+func Test_flattenStorageLayout(t *testing.T) {
+	// given
+	storage := &metalv1.Storage{
+		Disks: []metalv1.Disk{{
+			Device:    metalv1.PtrString("/dev/sda"),
+			WipeTable: metalv1.PtrBool(true),
+			Partitions: []metalv1.Partition{{
+				Label:  metalv1.PtrString("ROOT"),
+				Number: metalv1.PtrInt32(1),
+				Size:   metalv1.PtrString("0"),
+			}},
+		}},
+		Filesystems: []metalv1.Filesystem{{
+			Mount: &metalv1.Mount{
+				Device: metalv1.PtrString("/dev/sda1"),
+				Format: metalv1.PtrString("ext4"),
+				Point:  metalv1.PtrString("/"),
+			},
+		}},
+	}
+	// when
+	layout := flattenStorageLayout(storage)
+	// then
+	l := layout[0].(map[string]interface{})
+	disk := l["disk"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "/dev/sda", disk["device"])
+	assert.Equal(t, 1, disk["partition"].([]interface{})[0].(map[string]interface{})["number"])
+	assert.Empty(t, l["raid"])
+	assert.Equal(t, "/", l["filesystem"].([]interface{})[0].(map[string]interface{})["point"])
+	assert.NoError(t, checkStorageLayout(layout, nil))
+}
+
+func Test_storageLayoutRoundTrip(t *testing.T) {
+	// given
+	layout := testStorageLayout()
+	cpr, err := expandStorageLayout(layout)
+	assert.NoError(t, err)
+	cprJSON, err := json.Marshal(cpr)
+	assert.NoError(t, err)
+	storage := &metalv1.Storage{}
+	assert.NoError(t, json.Unmarshal(cprJSON, storage))
+	// when
+	flattened := flattenStorageLayout(storage)
+	// then
+	expected := layout[0].(map[string]interface{})["filesystem"].([]interface{})
+	filesystems := flattened[0].(map[string]interface{})["filesystem"].([]interface{})
+	assert.Len(t, filesystems, len(expected))
+	for i := range expected {
+		assert.Equal(t, convertStringArr(expected[i].(map[string]interface{})["options"].([]interface{})),
+			filesystems[i].(map[string]interface{})["options"], "options of filesystem %d match", i)
+	}
+}
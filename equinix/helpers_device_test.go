@@ -156,3 +156,44 @@ func Test_waitUntilReservationProvisionable(t *testing.T) {
 		})
 	}
 }
+
+func Test_plannedDeviceCapacity_count(t *testing.T) {
+	planned := &plannedDeviceCapacity{}
+	steps := []struct {
+		plan      string
+		deviceKey string
+		want      int
+	}{
+		{"c3.small.x86", "project/device-1", 1},
+		{"c3.small.x86", "project/device-2", 2},
+		// the same device diffed again is not counted twice
+		{"c3.small.x86", "project/device-1", 2},
+		{"c3.small.x86", "other-project/device-1", 3},
+		{"m3.large.x86", "project/device-3", 1},
+	}
+	for _, s := range steps {
+		if got := planned.count("sv", s.plan, s.deviceKey); got != s.want {
+			t.Errorf("count(%q, %q) = %d, want %d", s.plan, s.deviceKey, got, s.want)
+		}
+	}
+	// devices planned by another provider are not counted
+	if got := (&plannedDeviceCapacity{}).count("sv", "c3.small.x86", "project/device-4"); got != 1 {
+		t.Errorf("count of another provider = %d, want 1", got)
+	}
+}
+
+func Test_plannedDeviceCapacity_warning(t *testing.T) {
+	planned := &plannedDeviceCapacity{}
+	planned.setWarning("project/device-1", "not enough capacity")
+	if got := planned.warning("project/device-1"); got != "not enough capacity" {
+		t.Errorf("warning() = %q, want %q", got, "not enough capacity")
+	}
+	if got := planned.warning("project/device-2"); got != "" {
+		t.Errorf("warning() of device without warning = %q, want empty", got)
+	}
+	// a later check with enough capacity clears the warning
+	planned.setWarning("project/device-1", "")
+	if got := planned.warning("project/device-1"); got != "" {
+		t.Errorf("warning() after clearing = %q, want empty", got)
+	}
+}
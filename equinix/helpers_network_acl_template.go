@@ -0,0 +1,222 @@
+package equinix
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/equinix/ne-go"
+)
+
+// ACL templates are managed with ne-go, which models inbound rules only.
+// Outbound rules are read and replaced with raw REST requests.
+
+type aclTemplateRuleModel struct {
+	SeqNo       *int     `json:"seqNo,omitempty"`
+	Subnets     []string `json:"subnets,omitempty"`
+	Subnet      *string  `json:"subnet,omitempty"`
+	Protocol    *string  `json:"protocol,omitempty"`
+	SrcPort     *string  `json:"srcPort,omitempty"`
+	DstPort     *string  `json:"dstPort,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+type aclTemplateModel struct {
+	Name          *string                `json:"name,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	MetroCode     *string                `json:"metroCode,omitempty"`
+	InboundRules  []aclTemplateRuleModel `json:"inboundRules,omitempty"`
+	OutboundRules []aclTemplateRuleModel `json:"outboundRules"`
+}
+
+func getNetworkACLTemplateOutboundRules(c ne.Client, uuid string) ([]ne.ACLTemplateInboundRule, error) {
+	respBody := aclTemplateModel{}
+	if err := neRestExecute(c, http.MethodGet, nePath("/ne/v1/aclTemplates", uuid), nil, &respBody); err != nil {
+		return nil, err
+	}
+	return mapACLTemplateRulesFromModel(respBody.OutboundRules), nil
+}
+
+// replaceNetworkACLTemplateOutboundRules replaces the template with its
+// outbound rules, as the API replaces the whole template.
+func replaceNetworkACLTemplateOutboundRules(c ne.Client, uuid string, template ne.ACLTemplate, outboundRules []ne.ACLTemplateInboundRule) error {
+	reqBody := aclTemplateModel{
+		Name:          template.Name,
+		Description:   template.Description,
+		MetroCode:     template.MetroCode,
+		InboundRules:  mapACLTemplateRulesToModel(template.InboundRules),
+		OutboundRules: mapACLTemplateRulesToModel(outboundRules),
+	}
+	return neRestExecute(c, http.MethodPut, nePath("/ne/v1/aclTemplates", uuid), &reqBody, nil)
+}
+
+func mapACLTemplateRulesToModel(rules []ne.ACLTemplateInboundRule) []aclTemplateRuleModel {
+	transformed := make([]aclTemplateRuleModel, len(rules))
+	for i := range rules {
+		transformed[i] = aclTemplateRuleModel{
+			SeqNo:       rules[i].SeqNo,
+			Subnets:     rules[i].Subnets,
+			Subnet:      rules[i].Subnet,
+			Protocol:    rules[i].Protocol,
+			SrcPort:     rules[i].SrcPort,
+			DstPort:     rules[i].DstPort,
+			Description: rules[i].Description,
+		}
+	}
+	return transformed
+}
+
+func mapACLTemplateRulesFromModel(rules []aclTemplateRuleModel) []ne.ACLTemplateInboundRule {
+	if rules == nil {
+		return nil
+	}
+	transformed := make([]ne.ACLTemplateInboundRule, len(rules))
+	for i := range rules {
+		transformed[i] = ne.ACLTemplateInboundRule{
+			SeqNo:       rules[i].SeqNo,
+			Subnet:      rules[i].Subnet,
+			Protocol:    rules[i].Protocol,
+			SrcPort:     rules[i].SrcPort,
+			DstPort:     rules[i].DstPort,
+			Description: rules[i].Description,
+		}
+	}
+	return transformed
+}
+
+type aclPortRange struct {
+	from int
+	to   int
+}
+
+// parseACLPorts parses a port definition of a rule: up to 10 comma separated
+// ports, a port range or the word any.
+func parseACLPorts(ports string) ([]aclPortRange, error) {
+	if ports == "any" {
+		return []aclPortRange{{0, 65535}}, nil
+	}
+	if from, to, ok := strings.Cut(ports, "-"); ok {
+		fromPort, fromErr := strconv.Atoi(from)
+		toPort, toErr := strconv.Atoi(to)
+		if fromErr != nil || toErr != nil {
+			return nil, fmt.Errorf("invalid port range %q", ports)
+		}
+		return []aclPortRange{{fromPort, toPort}}, nil
+	}
+	ranges := []aclPortRange{}
+	for _, p := range strings.Split(ports, ",") {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", p)
+		}
+		ranges = append(ranges, aclPortRange{port, port})
+	}
+	return ranges, nil
+}
+
+// aclPortsCover tells if all the ports of the second definition are also
+// ports of the first one.
+func aclPortsCover(ports, other []aclPortRange) bool {
+	for _, o := range other {
+		covered := false
+		for _, p := range ports {
+			if p.from <= o.from && o.to <= p.to {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func aclRuleSubnets(rule ne.ACLTemplateInboundRule) ([]*net.IPNet, error) {
+	subnets := append([]string{}, rule.Subnets...)
+	if ne.StringValue(rule.Subnet) != "" {
+		subnets = append(subnets, ne.StringValue(rule.Subnet))
+	}
+	transformed := make([]*net.IPNet, len(subnets))
+	for i := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnets[i])
+		if err != nil {
+			return nil, err
+		}
+		transformed[i] = ipNet
+	}
+	return transformed, nil
+}
+
+// aclSubnetsCover tells if all the subnets of the second list are within the
+// subnets of the first one.
+func aclSubnetsCover(subnets, other []*net.IPNet) bool {
+	for _, o := range other {
+		covered := false
+		oOnes, oBits := o.Mask.Size()
+		for _, s := range subnets {
+			ones, bits := s.Mask.Size()
+			if bits == oBits && ones <= oOnes && s.Contains(o.IP) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// aclRuleCovers tells if the rule matches all the traffic matched by the other
+// rule.
+func aclRuleCovers(rule, other ne.ACLTemplateInboundRule) bool {
+	protocol := ne.StringValue(rule.Protocol)
+	if protocol != "IP" && protocol != ne.StringValue(other.Protocol) {
+		return false
+	}
+	subnets, err := aclRuleSubnets(rule)
+	if err != nil || len(subnets) == 0 {
+		return false
+	}
+	otherSubnets, err := aclRuleSubnets(other)
+	if err != nil || len(otherSubnets) == 0 || !aclSubnetsCover(subnets, otherSubnets) {
+		return false
+	}
+	if protocol == "IP" {
+		return true
+	}
+	for _, ports := range [][2]*string{{rule.SrcPort, other.SrcPort}, {rule.DstPort, other.DstPort}} {
+		rulePorts, err := parseACLPorts(ne.StringValue(ports[0]))
+		if err != nil {
+			return false
+		}
+		otherPorts, err := parseACLPorts(ne.StringValue(ports[1]))
+		if err != nil || !aclPortsCover(rulePorts, otherPorts) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkACLTemplateRules checks that sequence numbers of the rules are unique
+// and that no rule is shadowed by a rule with a lower sequence number, which
+// matches all of its traffic.
+func checkACLTemplateRules(ruleType string, rules []ne.ACLTemplateInboundRule) error {
+	sorted := append([]ne.ACLTemplateInboundRule{}, rules...)
+	sortACLTemplateRules(sorted)
+	for i := range sorted {
+		seqNo := ne.IntValue(sorted[i].SeqNo)
+		if i > 0 && seqNo == ne.IntValue(sorted[i-1].SeqNo) {
+			return fmt.Errorf("%s rules have conflicting sequence number %d", ruleType, seqNo)
+		}
+		for j := 0; j < i; j++ {
+			if aclRuleCovers(sorted[j], sorted[i]) {
+				return fmt.Errorf("%s rule %d is shadowed by %s rule %d, which matches all of its traffic", ruleType, seqNo, ruleType, ne.IntValue(sorted[j].SeqNo))
+			}
+		}
+	}
+	return nil
+}
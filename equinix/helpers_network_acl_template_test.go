@@ -0,0 +1,69 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkACLTemplate_checkRules(t *testing.T) {
+	// given
+	rule := func(seqNo int, subnet, protocol, srcPort, dstPort string) ne.ACLTemplateInboundRule {
+		return ne.ACLTemplateInboundRule{
+			SeqNo:    ne.Int(seqNo),
+			Subnet:   ne.String(subnet),
+			Protocol: ne.String(protocol),
+			SrcPort:  ne.String(srcPort),
+			DstPort:  ne.String(dstPort),
+		}
+	}
+	valid := []ne.ACLTemplateInboundRule{
+		rule(2, "10.0.0.0/24", "TCP", "any", "443"),
+		rule(1, "10.0.1.0/24", "TCP", "any", "22-23"),
+		rule(3, "10.0.0.0/16", "UDP", "any", "53"),
+	}
+	conflicting := []ne.ACLTemplateInboundRule{
+		rule(1, "10.0.0.0/24", "TCP", "any", "443"),
+		rule(1, "10.0.1.0/24", "UDP", "any", "53"),
+	}
+	shadowed := []ne.ACLTemplateInboundRule{
+		rule(2, "10.0.0.0/28", "TCP", "any", "22,23"),
+		rule(1, "10.0.0.0/24", "TCP", "any", "20-30"),
+	}
+	shadowedByIP := []ne.ACLTemplateInboundRule{
+		rule(1, "0.0.0.0/0", "IP", "any", "any"),
+		rule(5, "192.168.0.1/32", "UDP", "any", "53"),
+	}
+	// when
+	validErr := checkACLTemplateRules("inbound", valid)
+	conflictingErr := checkACLTemplateRules("inbound", conflicting)
+	shadowedErr := checkACLTemplateRules("outbound", shadowed)
+	shadowedByIPErr := checkACLTemplateRules("inbound", shadowedByIP)
+	// then
+	assert.Nil(t, validErr, "Rules matching different traffic are accepted")
+	assert.EqualError(t, conflictingErr, "inbound rules have conflicting sequence number 1")
+	assert.EqualError(t, shadowedErr, "outbound rule 2 is shadowed by outbound rule 1, which matches all of its traffic")
+	assert.EqualError(t, shadowedByIPErr, "inbound rule 5 is shadowed by inbound rule 1, which matches all of its traffic")
+}
+
+func TestNetworkACLTemplate_mapRules(t *testing.T) {
+	// given
+	rules := []ne.ACLTemplateInboundRule{
+		{
+			SeqNo:       ne.Int(1),
+			Subnet:      ne.String("10.0.0.0/24"),
+			Protocol:    ne.String("TCP"),
+			SrcPort:     ne.String("any"),
+			DstPort:     ne.String("443"),
+			Description: ne.String("https"),
+		},
+	}
+	// when
+	result := mapACLTemplateRulesFromModel(mapACLTemplateRulesToModel(rules))
+	cleared := mapACLTemplateRulesToModel(nil)
+	// then
+	assert.Equal(t, rules, result, "Rules mapped to API model and back match")
+	assert.NotNil(t, cleared, "Missing outbound rules are sent as an empty list")
+	assert.Empty(t, cleared, "Missing outbound rules are sent as an empty list")
+}
@@ -0,0 +1,101 @@
+package equinix
+
+import (
+	"net/http"
+
+	"github.com/equinix/ne-go"
+)
+
+// BGP peering configurations are managed with ne-go, which does not model
+// peering options and prefix counts. Options are sent only when they are
+// configured, in an update request which carries the peering configuration
+// along, as updates replace the whole configuration.
+
+// networkBGPOptions are the optional BGP peering settings.
+type networkBGPOptions struct {
+	EBGPMultihop   *int
+	KeepaliveTimer *int
+	HoldTimer      *int
+	PrefixLimit    *int
+	BFDEnabled     *bool
+	BFDInterval    *int
+	BFDMultiplier  *int
+}
+
+// networkBGPDetails are the options of a BGP peering configuration with
+// prefix counts of the established session.
+type networkBGPDetails struct {
+	Options            networkBGPOptions
+	ReceivedPrefixes   *int
+	AdvertisedPrefixes *int
+}
+
+type bgpConfigurationOptionsModel struct {
+	LocalIPAddress     *string `json:"localIpAddress,omitempty"`
+	LocalASN           *int    `json:"localAsn,omitempty"`
+	RemoteASN          *int    `json:"remoteAsn,omitempty"`
+	RemoteIPAddress    *string `json:"remoteIpAddress,omitempty"`
+	AuthenticationKey  *string `json:"authenticationKey,omitempty"`
+	EBGPMultihop       *int    `json:"ebgpMultihop,omitempty"`
+	KeepaliveTimer     *int    `json:"keepaliveTimer,omitempty"`
+	HoldTimer          *int    `json:"holdTimer,omitempty"`
+	PrefixLimit        *int    `json:"maxPrefixes,omitempty"`
+	BFDEnabled         *bool   `json:"bfdEnabled,omitempty"`
+	BFDInterval        *int    `json:"bfdInterval,omitempty"`
+	BFDMultiplier      *int    `json:"bfdMultiplier,omitempty"`
+	ReceivedPrefixes   *int    `json:"receivedPrefixCount,omitempty"`
+	AdvertisedPrefixes *int    `json:"advertisedPrefixCount,omitempty"`
+}
+
+// isSet returns true when any of the options is configured, so that peering
+// configurations without options are never sent the option fields.
+func (o networkBGPOptions) isSet() bool {
+	return o.EBGPMultihop != nil || o.KeepaliveTimer != nil || o.HoldTimer != nil || o.PrefixLimit != nil ||
+		ne.BoolValue(o.BFDEnabled) || o.BFDInterval != nil || o.BFDMultiplier != nil
+}
+
+func updateNetworkBGPOptions(c ne.Client, bgp ne.BGPConfiguration, options networkBGPOptions) error {
+	reqBody := mapNetworkBGPOptionsToModel(bgp, options)
+	return neRestExecute(c, http.MethodPut, nePath("/ne/v1/bgp", ne.StringValue(bgp.UUID)), &reqBody, nil)
+}
+
+func getNetworkBGPDetails(c ne.Client, uuid string) (*networkBGPDetails, error) {
+	respBody := bgpConfigurationOptionsModel{}
+	if err := neRestExecute(c, http.MethodGet, nePath("/ne/v1/bgp", uuid), nil, &respBody); err != nil {
+		return nil, err
+	}
+	return mapNetworkBGPDetailsFromModel(respBody), nil
+}
+
+func mapNetworkBGPOptionsToModel(bgp ne.BGPConfiguration, options networkBGPOptions) bgpConfigurationOptionsModel {
+	return bgpConfigurationOptionsModel{
+		LocalIPAddress:    bgp.LocalIPAddress,
+		LocalASN:          bgp.LocalASN,
+		RemoteIPAddress:   bgp.RemoteIPAddress,
+		RemoteASN:         bgp.RemoteASN,
+		AuthenticationKey: bgp.AuthenticationKey,
+		EBGPMultihop:      options.EBGPMultihop,
+		KeepaliveTimer:    options.KeepaliveTimer,
+		HoldTimer:         options.HoldTimer,
+		PrefixLimit:       options.PrefixLimit,
+		BFDEnabled:        options.BFDEnabled,
+		BFDInterval:       options.BFDInterval,
+		BFDMultiplier:     options.BFDMultiplier,
+	}
+}
+
+func mapNetworkBGPDetailsFromModel(model bgpConfigurationOptionsModel) *networkBGPDetails {
+	return &networkBGPDetails{
+		Options: networkBGPOptions{
+			EBGPMultihop:   model.EBGPMultihop,
+			KeepaliveTimer: model.KeepaliveTimer,
+			HoldTimer:      model.HoldTimer,
+			PrefixLimit:    model.PrefixLimit,
+			BFDEnabled:     model.BFDEnabled,
+			BFDInterval:    model.BFDInterval,
+			BFDMultiplier:  model.BFDMultiplier,
+		},
+		ReceivedPrefixes:   model.ReceivedPrefixes,
+		AdvertisedPrefixes: model.AdvertisedPrefixes,
+	}
+}
@@ -0,0 +1,212 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+const (
+	neDeviceUpgradeTypeSoftware   = "SOFTWARE"
+	neDeviceUpgradeTypeCore       = "CORE"
+	neDeviceUpgradeTypePackage    = "PACKAGE"
+	neDeviceUpgradeTypeThroughput = "THROUGHPUT"
+
+	neDeviceUpgradeStateUpgrading = "UPGRADING"
+	neDeviceUpgradeStateUpgraded  = "UPGRADED"
+)
+
+type upgradeDevice func(uuid string, request map[string]interface{}) error
+
+// neDeviceUpgrader returns the function which requests an upgrade of a
+// device, the upgrade type and its parameters are in the request.
+func neDeviceUpgrader(c ne.Client) upgradeDevice {
+	return func(uuid string, request map[string]interface{}) error {
+		return neRestExecute(c, http.MethodPost, nePath("/ne/v1/devices", uuid, "upgrade"), request, nil)
+	}
+}
+
+// checkNetworkDeviceSoftwareVersion checks that the version is offered for
+// the device type and that it supports the package.
+func checkNetworkDeviceSoftwareVersion(versions []ne.DeviceSoftwareVersion, typeCode, packageCode, version string) error {
+	for _, v := range versions {
+		if ne.StringValue(v.Version) != version {
+			continue
+		}
+		if packageCode != "" && len(v.PackageCodes) > 0 && !isStringInSlice(packageCode, v.PackageCodes) {
+			return fmt.Errorf("version %q of device type %q does not support package %q, supported packages: %v", version, typeCode, packageCode, v.PackageCodes)
+		}
+		return nil
+	}
+	return fmt.Errorf("version %q is not available for device type %q, see the equinix_network_device_software data source", version, typeCode)
+}
+
+// createNetworkDeviceUpgradeWaitConfiguration waits until the device is
+// provisioned and upgraded, which is told by the upgraded func.
+func createNetworkDeviceUpgradeWaitConfiguration(fetchFunc getDevice, id, change string, upgraded func(*ne.Device) bool, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending: []string{
+			neDeviceUpgradeStateUpgrading,
+		},
+		Target: []string{
+			neDeviceUpgradeStateUpgraded,
+		},
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: delay,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := fetchFunc(id)
+			if err != nil {
+				return nil, "", err
+			}
+			status := ne.StringValue(resp.Status)
+			if status == ne.DeviceStateFailed {
+				return nil, "", fmt.Errorf("device %s failed during the change of %s", id, change)
+			}
+			if status == ne.DeviceStateProvisioned && upgraded(resp) {
+				return resp, neDeviceUpgradeStateUpgraded, nil
+			}
+			return resp, neDeviceUpgradeStateUpgrading, nil
+		},
+	}
+}
+
+func createNetworkDeviceVersionUpgradeWaitConfiguration(fetchFunc getDevice, id, version string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return createNetworkDeviceUpgradeWaitConfiguration(fetchFunc, id, fmt.Sprintf("version to %q", version), func(device *ne.Device) bool {
+		return ne.StringValue(device.Version) == version
+	}, delay, timeout)
+}
+
+func createNetworkDeviceThroughputResizeWaitConfiguration(fetchFunc getDevice, id string, throughput int, throughputUnit string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return createNetworkDeviceUpgradeWaitConfiguration(fetchFunc, id, fmt.Sprintf("throughput to %d %s", throughput, throughputUnit), func(device *ne.Device) bool {
+		return ne.IntValue(device.Throughput) == throughput && ne.StringValue(device.ThroughputUnit) == throughputUnit
+	}, delay, timeout)
+}
+
+func createNetworkDevicePackageResizeWaitConfiguration(fetchFunc getDevice, id, packageCode string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return createNetworkDeviceUpgradeWaitConfiguration(fetchFunc, id, fmt.Sprintf("package to %q", packageCode), func(device *ne.Device) bool {
+		return ne.StringValue(device.PackageCode) == packageCode
+	}, delay, timeout)
+}
+
+func createNetworkDeviceCoreResizeWaitConfiguration(fetchFunc getDevice, id string, coreCount int, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return createNetworkDeviceUpgradeWaitConfiguration(fetchFunc, id, fmt.Sprintf("core count to %d", coreCount), func(device *ne.Device) bool {
+		return ne.IntValue(device.CoreCount) == coreCount
+	}, delay, timeout)
+}
+
+func createNetworkDeviceInterfaceResizeWaitConfiguration(fetchFunc getDevice, id string, interfaceCount int, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return createNetworkDeviceUpgradeWaitConfiguration(fetchFunc, id, fmt.Sprintf("interface count to %d", interfaceCount), func(device *ne.Device) bool {
+		return ne.IntValue(device.InterfaceCount) == interfaceCount
+	}, delay, timeout)
+}
+
+// upgradeNetworkDevices upgrades the devices one at a time, so that an HA
+// pair or a cluster always has a working node. Each device is upgraded only
+// after the previous one is provisioned with the change.
+func upgradeNetworkDevices(ctx context.Context, upgradeFunc upgradeDevice, deviceIDs []string, request map[string]interface{}, waitConfigFunc func(id string, timeout time.Duration) *retry.StateChangeConf, timeout time.Duration) error {
+	start := time.Now()
+	for _, id := range deviceIDs {
+		if err := upgradeFunc(id, request); err != nil {
+			return fmt.Errorf("could not upgrade network device %s: %s", id, err)
+		}
+		if _, err := waitConfigFunc(id, timeout-time.Since(start)).WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("error waiting for network device %s to be upgraded: %s", id, err)
+		}
+	}
+	return nil
+}
+
+func upgradeNetworkDeviceVersion(ctx context.Context, upgradeFunc upgradeDevice, fetchFunc getDevice, deviceIDs []string, version string, timeout time.Duration) error {
+	request := map[string]interface{}{
+		"upgradeType": neDeviceUpgradeTypeSoftware,
+		"version":     version,
+	}
+	return upgradeNetworkDevices(ctx, upgradeFunc, deviceIDs, request, func(id string, timeout time.Duration) *retry.StateChangeConf {
+		return createNetworkDeviceVersionUpgradeWaitConfiguration(fetchFunc, id, version, 5*time.Second, timeout)
+	}, timeout)
+}
+
+// resizeNetworkDevice changes the core count, package, throughput and
+// interface count of the devices, in this order, each change with its own
+// wait configuration.
+func resizeNetworkDevice(ctx context.Context, upgradeFunc upgradeDevice, fetchFunc getDevice, deviceIDs []string, changes map[string]interface{}, timeout time.Duration) error {
+	start := time.Now()
+	if v, ok := changes[neDeviceSchemaNames["CoreCount"]]; ok {
+		coreCount := v.(int)
+		request := map[string]interface{}{
+			"upgradeType": neDeviceUpgradeTypeCore,
+			"core":        coreCount,
+		}
+		if err := upgradeNetworkDevices(ctx, upgradeFunc, deviceIDs, request, func(id string, timeout time.Duration) *retry.StateChangeConf {
+			return createNetworkDeviceCoreResizeWaitConfiguration(fetchFunc, id, coreCount, 5*time.Second, timeout)
+		}, timeout-time.Since(start)); err != nil {
+			return err
+		}
+	}
+	if v, ok := changes[neDeviceSchemaNames["PackageCode"]]; ok {
+		packageCode := v.(string)
+		request := map[string]interface{}{
+			"upgradeType": neDeviceUpgradeTypePackage,
+			"packageCode": packageCode,
+		}
+		if err := upgradeNetworkDevices(ctx, upgradeFunc, deviceIDs, request, func(id string, timeout time.Duration) *retry.StateChangeConf {
+			return createNetworkDevicePackageResizeWaitConfiguration(fetchFunc, id, packageCode, 5*time.Second, timeout)
+		}, timeout-time.Since(start)); err != nil {
+			return err
+		}
+	}
+	if v, ok := changes[neDeviceSchemaNames["Throughput"]]; ok {
+		throughput := v.(int)
+		throughputUnit := changes[neDeviceSchemaNames["ThroughputUnit"]].(string)
+		request := map[string]interface{}{
+			"upgradeType":    neDeviceUpgradeTypeThroughput,
+			"throughput":     throughput,
+			"throughputUnit": throughputUnit,
+		}
+		if err := upgradeNetworkDevices(ctx, upgradeFunc, deviceIDs, request, func(id string, timeout time.Duration) *retry.StateChangeConf {
+			return createNetworkDeviceThroughputResizeWaitConfiguration(fetchFunc, id, throughput, throughputUnit, 5*time.Second, timeout)
+		}, timeout-time.Since(start)); err != nil {
+			return err
+		}
+	}
+	if v, ok := changes[neDeviceSchemaNames["InterfaceCount"]]; ok {
+		interfaceCount := v.(int)
+		request := map[string]interface{}{
+			"upgradeType":    neDeviceUpgradeTypeInterface,
+			"interfaceCount": interfaceCount,
+		}
+		if err := upgradeNetworkDevices(ctx, upgradeFunc, deviceIDs, request, func(id string, timeout time.Duration) *retry.StateChangeConf {
+			return createNetworkDeviceInterfaceResizeWaitConfiguration(fetchFunc, id, interfaceCount, 5*time.Second, timeout)
+		}, timeout-time.Since(start)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNetworkDevicePlatform checks that the device type has a platform with
+// the core count, which supports the package, management type and license
+// mode of the device.
+func checkNetworkDevicePlatform(platforms []ne.DevicePlatform, typeCode string, coreCount int, packageCode, managementType, licenseMode string) error {
+	for _, p := range platforms {
+		if ne.IntValue(p.CoreCount) != coreCount {
+			continue
+		}
+		if len(p.PackageCodes) > 0 && !isStringInSlice(packageCode, p.PackageCodes) {
+			continue
+		}
+		if len(p.ManagementTypes) > 0 && !isStringInSlice(managementType, p.ManagementTypes) {
+			continue
+		}
+		if len(p.LicenseOptions) > 0 && !isStringInSlice(licenseMode, p.LicenseOptions) {
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("device type %q has no platform with %d cores supporting package %q, %s management and %s licensing, see the equinix_network_device_platform data source", typeCode, coreCount, packageCode, managementType, licenseMode)
+}
@@ -0,0 +1,90 @@
+package equinix
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/equinix/ne-go"
+)
+
+const (
+	neDeviceBackupStatusPending   = "PENDING"
+	neDeviceBackupStatusCompleted = "COMPLETED"
+	neDeviceBackupStatusFailed    = "FAILED"
+
+	neDeviceStateRestoring = "RESTORING"
+)
+
+type networkDeviceBackup struct {
+	UUID            *string `json:"uuid,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	DeviceUUID      *string `json:"deviceUuid,omitempty"`
+	Version         *string `json:"version,omitempty"`
+	Type            *string `json:"type,omitempty"`
+	Status          *string `json:"status,omitempty"`
+	DownloadURL     *string `json:"downloadUrl,omitempty"`
+	DeleteAllowed   *bool   `json:"deleteAllowed,omitempty"`
+	CreatedBy       *string `json:"createdBy,omitempty"`
+	LastUpdatedDate *string `json:"lastUpdatedDate,omitempty"`
+}
+
+type networkDeviceBackupsPagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+type networkDeviceBackupsResponse struct {
+	Pagination networkDeviceBackupsPagination `json:"pagination"`
+	Data       []networkDeviceBackup          `json:"data"`
+}
+
+type getDeviceBackup func(uuid string) (*networkDeviceBackup, error)
+
+func createNetworkDeviceBackup(c ne.Client, deviceUUID, name string) (*string, error) {
+	reqBody := networkDeviceBackup{
+		DeviceUUID: ne.String(deviceUUID),
+		Name:       ne.String(name),
+	}
+	respBody := networkDeviceBackup{}
+	if err := neRestExecute(c, http.MethodPost, "/ne/v1/deviceBackups", &reqBody, &respBody); err != nil {
+		return nil, err
+	}
+	return respBody.UUID, nil
+}
+
+func networkDeviceBackupGetter(c ne.Client) getDeviceBackup {
+	return func(uuid string) (*networkDeviceBackup, error) {
+		respBody := networkDeviceBackup{}
+		if err := neRestExecute(c, http.MethodGet, nePath("/ne/v1/deviceBackups", uuid), nil, &respBody); err != nil {
+			return nil, err
+		}
+		return &respBody, nil
+	}
+}
+
+func getNetworkDeviceBackups(c ne.Client, deviceUUID string, statuses []string) ([]networkDeviceBackup, error) {
+	params := map[string]string{
+		"virtualDeviceUuid": deviceUUID,
+	}
+	if len(statuses) > 0 {
+		params["status"] = strings.Join(statuses, ",")
+	}
+	content, err := neRestGetOffsetPaginated(c, "/ne/v1/deviceBackups", &networkDeviceBackupsResponse{}, params)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]networkDeviceBackup, len(content))
+	for i := range content {
+		backups[i] = content[i].(networkDeviceBackup)
+	}
+	return backups, nil
+}
+
+func deleteNetworkDeviceBackup(c ne.Client, uuid string) error {
+	return neRestExecute(c, http.MethodDelete, nePath("/ne/v1/deviceBackups", uuid), nil, nil)
+}
+
+func restoreNetworkDeviceBackup(c ne.Client, uuid string) error {
+	return neRestExecute(c, http.MethodPatch, nePath("/ne/v1/deviceBackups", uuid, "restore"), map[string]interface{}{}, nil)
+}
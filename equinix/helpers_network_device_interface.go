@@ -0,0 +1,103 @@
+package equinix
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/equinix/ne-go"
+)
+
+const (
+	neDeviceUpgradeTypeInterface = "INTERFACE"
+
+	neDeviceInterfaceStatusAvailable = "AVAILABLE"
+)
+
+type networkDeviceInterface struct {
+	ID                     *int    `json:"id,omitempty"`
+	Name                   *string `json:"name,omitempty"`
+	Status                 *string `json:"status,omitempty"`
+	OperationalStatus      *string `json:"operationalStatus,omitempty"`
+	MACAddress             *string `json:"macAddress,omitempty"`
+	IPAddress              *string `json:"ipAddress,omitempty"`
+	AssignedType           *string `json:"assignedType,omitempty"`
+	Type                   *string `json:"type,omitempty"`
+	AssignedConnectionUUID *string `json:"assignedConnectionUuid,omitempty"`
+}
+
+type networkDeviceInterfacesResponse struct {
+	Interfaces []networkDeviceInterface `json:"interfaces"`
+}
+
+type networkDeviceTypeInterfaceLimits struct {
+	Code                     *string `json:"deviceTypeCode,omitempty"`
+	MaxInterfaceCount        *int    `json:"maxInterfaceCount,omitempty"`
+	ClusterMaxInterfaceCount *int    `json:"clusterMaxInterfaceCount,omitempty"`
+}
+
+type networkDeviceTypesPagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+type networkDeviceTypesResponse struct {
+	Pagination networkDeviceTypesPagination       `json:"pagination"`
+	Data       []networkDeviceTypeInterfaceLimits `json:"data"`
+}
+
+func getNetworkDeviceInterfaces(c ne.Client, deviceUUID string) ([]networkDeviceInterface, error) {
+	respBody := networkDeviceInterfacesResponse{}
+	if err := neRestExecute(c, http.MethodGet, nePath("/ne/v1/devices", deviceUUID), nil, &respBody); err != nil {
+		return nil, err
+	}
+	return respBody.Interfaces, nil
+}
+
+func getNetworkDeviceTypeInterfaceLimits(c ne.Client, typeCode string) (*networkDeviceTypeInterfaceLimits, error) {
+	content, err := neRestGetOffsetPaginated(c, "/ne/v1/deviceTypes", &networkDeviceTypesResponse{},
+		map[string]string{"deviceTypeCode": typeCode})
+	if err != nil {
+		return nil, err
+	}
+	for i := range content {
+		limits := content[i].(networkDeviceTypeInterfaceLimits)
+		if ne.StringValue(limits.Code) == typeCode {
+			return &limits, nil
+		}
+	}
+	return nil, fmt.Errorf("device type %q was not found", typeCode)
+}
+
+// findNextFreeNetworkDeviceInterface returns the available interface with the
+// lowest identifier, or nil when all interfaces are taken. Interfaces can be
+// narrowed to the given type.
+func findNextFreeNetworkDeviceInterface(interfaces []networkDeviceInterface, interfaceType string) *networkDeviceInterface {
+	var next *networkDeviceInterface
+	for i := range interfaces {
+		if ne.StringValue(interfaces[i].Status) != neDeviceInterfaceStatusAvailable {
+			continue
+		}
+		if interfaceType != "" && ne.StringValue(interfaces[i].Type) != interfaceType {
+			continue
+		}
+		if next == nil || ne.IntValue(interfaces[i].ID) < ne.IntValue(next.ID) {
+			next = &interfaces[i]
+		}
+	}
+	return next
+}
+
+// checkNetworkDeviceInterfaceCount checks that the device type supports the
+// interface count. Limits which are not given by the device type are not
+// checked.
+func checkNetworkDeviceInterfaceCount(limits networkDeviceTypeInterfaceLimits, interfaceCount int, isCluster bool) error {
+	maxCount := ne.IntValue(limits.MaxInterfaceCount)
+	if isCluster {
+		maxCount = ne.IntValue(limits.ClusterMaxInterfaceCount)
+	}
+	if maxCount > 0 && interfaceCount > maxCount {
+		return fmt.Errorf("device type %q supports at most %d interfaces, got %d", ne.StringValue(limits.Code), maxCount, interfaceCount)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDevice_findNextFreeInterface(t *testing.T) {
+	// given
+	interfaces := []networkDeviceInterface{
+		{ID: ne.Int(1), Status: ne.String("ASSIGNED"), Type: ne.String("MGMT")},
+		{ID: ne.Int(4), Status: ne.String(neDeviceInterfaceStatusAvailable), Type: ne.String("DATA")},
+		{ID: ne.Int(2), Status: ne.String(neDeviceInterfaceStatusAvailable), Type: ne.String("WAN")},
+		{ID: ne.Int(3), Status: ne.String("ASSIGNED"), Type: ne.String("DATA"), AssignedConnectionUUID: ne.String("conn")},
+	}
+	// when
+	next := findNextFreeNetworkDeviceInterface(interfaces, "")
+	nextData := findNextFreeNetworkDeviceInterface(interfaces, "DATA")
+	none := findNextFreeNetworkDeviceInterface(interfaces[:1], "")
+	// then
+	assert.Equal(t, 2, ne.IntValue(next.ID), "Available interface with lowest identifier is picked")
+	assert.Equal(t, 4, ne.IntValue(nextData.ID), "Available interface of a given type is picked")
+	assert.Nil(t, none, "No interface is picked when all are taken")
+}
+
+func TestNetworkDevice_checkInterfaceCount(t *testing.T) {
+	// given
+	limits := networkDeviceTypeInterfaceLimits{
+		Code:                     ne.String("CSR1000V"),
+		MaxInterfaceCount:        ne.Int(24),
+		ClusterMaxInterfaceCount: ne.Int(10),
+	}
+	// when
+	validErr := checkNetworkDeviceInterfaceCount(limits, 24, false)
+	tooManyErr := checkNetworkDeviceInterfaceCount(limits, 32, false)
+	clusterErr := checkNetworkDeviceInterfaceCount(limits, 24, true)
+	unknownErr := checkNetworkDeviceInterfaceCount(networkDeviceTypeInterfaceLimits{Code: ne.String("VSRX")}, 32, false)
+	// then
+	assert.Nil(t, validErr, "Interface count within the device type limit is valid")
+	assert.Error(t, tooManyErr, "Interface count over the device type limit is not valid")
+	assert.Error(t, clusterErr, "Interface count over the cluster limit is not valid")
+	assert.Nil(t, unknownErr, "Interface count is not checked without a device type limit")
+}
+
+func TestNetworkDeviceInterfaces_updateDataSource(t *testing.T) {
+	// given
+	interfaces := []networkDeviceInterface{
+		{ID: ne.Int(3), Name: ne.String("GigabitEthernet3"), Status: ne.String("ASSIGNED"), MACAddress: ne.String("fa:16:3e:6e:3a:0c"), Type: ne.String("DATA"), AssignedConnectionUUID: ne.String("a2b4c6d8")},
+		{ID: ne.Int(4), Name: ne.String("GigabitEthernet4"), Status: ne.String(neDeviceInterfaceStatusAvailable), Type: ne.String("DATA")},
+	}
+	d := dataSourceNetworkDeviceInterfaces().TestResourceData()
+	// when
+	err := updateNetworkDeviceInterfacesDataSource(interfaces, "DATA", d)
+	// then
+	assert.Nil(t, err, "Update of data source does not return error")
+	assert.Equal(t, 2, d.Get(networkDeviceInterfacesSchemaNames["Interfaces"]+".#"), "Interfaces are set")
+	assert.Equal(t, "a2b4c6d8", d.Get(networkDeviceInterfacesSchemaNames["Interfaces"]+".0."+networkDeviceInterfaceSchemaNames["AssignedConnectionUUID"]), "Assigned connection matches")
+	assert.Equal(t, "fa:16:3e:6e:3a:0c", d.Get(networkDeviceInterfacesSchemaNames["Interfaces"]+".0."+neDeviceInterfaceSchemaNames["MACAddress"]), "MAC address matches")
+	assert.Equal(t, 4, d.Get(networkDeviceInterfacesSchemaNames["NextFreeInterfaceID"]), "Next free interface matches")
+}
@@ -0,0 +1,138 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type networkDeviceLicenseDetails struct {
+	LicenseExpirationDate *string `json:"licenseExpirationDate,omitempty"`
+}
+
+type (
+	getDeviceLicenseDetails func(uuid string) (*networkDeviceLicenseDetails, error)
+	updateDeviceLicense     func(uuid string, token, fileID string) error
+)
+
+func networkDeviceLicenseDetailsGetter(c ne.Client) getDeviceLicenseDetails {
+	return func(uuid string) (*networkDeviceLicenseDetails, error) {
+		respBody := networkDeviceLicenseDetails{}
+		if err := neRestExecute(c, http.MethodGet, nePath("/ne/v1/devices", uuid), nil, &respBody); err != nil {
+			return nil, err
+		}
+		return &respBody, nil
+	}
+}
+
+// networkDeviceLicenseUpdater returns the function which applies a license
+// token or an already uploaded license file on an existing device.
+func networkDeviceLicenseUpdater(c ne.Client) updateDeviceLicense {
+	return func(uuid string, token, fileID string) error {
+		if token != "" {
+			return neRestExecute(c, http.MethodPut, nePath("/ne/v1/devices", uuid, "licenseTokens"), map[string]interface{}{"token": token}, nil)
+		}
+		return neRestExecute(c, http.MethodPut, nePath("/ne/v1/devices", uuid, "licenseFiles"), map[string]interface{}{"licenseFileId": fileID}, nil)
+	}
+}
+
+// updateNetworkDeviceLicense uploads the license file of the device, when
+// given, applies the license token or file and waits until the license is
+// registered again.
+func updateNetworkDeviceLicense(ctx context.Context, c ne.Client, typeCode string, device *ne.Device, timeout time.Duration) error {
+	if err := uploadDeviceLicenseFile(os.Open, c.UploadLicenseFile, typeCode, device); err != nil {
+		return fmt.Errorf("could not upload license file of network device %s: %s", ne.StringValue(device.UUID), err)
+	}
+	if err := networkDeviceLicenseUpdater(c)(ne.StringValue(device.UUID), ne.StringValue(device.LicenseToken), ne.StringValue(device.LicenseFileID)); err != nil {
+		return fmt.Errorf("could not update license of network device %s: %s", ne.StringValue(device.UUID), err)
+	}
+	if _, err := createNetworkDeviceLicenseUpdateWaitConfiguration(c.GetDevice, ne.StringValue(device.UUID), 30*time.Second, 5*time.Second, timeout).WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for license of network device %s to be updated: %s", ne.StringValue(device.UUID), err)
+	}
+	return nil
+}
+
+// createNetworkDeviceLicenseUpdateWaitConfiguration waits for the license to
+// be registered like on device creation. The first check is delayed, as the
+// license of an existing device is still registered right after the update
+// request.
+func createNetworkDeviceLicenseUpdateWaitConfiguration(fetchFunc getDevice, id string, initialDelay time.Duration, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	conf := createNetworkDeviceLicenseStatusWaitConfiguration(fetchFunc, id, delay, timeout)
+	conf.Delay = initialDelay
+	return conf
+}
+
+// getNetworkDeviceLicenseChange returns the device with the license to apply
+// when the license token or file of the device under the given key prefix has
+// changed. Removed licenses are not applied, as a device can't be left
+// without a license.
+func getNetworkDeviceLicenseChange(d resourceDataProvider, prefix string) *ne.Device {
+	keys := map[string]string{
+		"LicenseToken":  prefix + neDeviceSchemaNames["LicenseToken"],
+		"LicenseFile":   prefix + neDeviceSchemaNames["LicenseFile"],
+		"LicenseFileID": prefix + neDeviceSchemaNames["LicenseFileID"],
+	}
+	changes := getResourceDataChangedKeys([]string{keys["LicenseToken"], keys["LicenseFile"], keys["LicenseFileID"]}, d)
+	device := &ne.Device{}
+	if v, ok := changes[keys["LicenseToken"]]; ok && v.(string) != "" {
+		device.LicenseToken = ne.String(v.(string))
+		return device
+	}
+	if v, ok := changes[keys["LicenseFile"]]; ok && v.(string) != "" {
+		device.LicenseFile = ne.String(v.(string))
+		return device
+	}
+	if v, ok := changes[keys["LicenseFileID"]]; ok && v.(string) != "" {
+		device.LicenseFileID = ne.String(v.(string))
+		return device
+	}
+	return nil
+}
+
+// updateNetworkDeviceLicenseExpiration sets the license expiration date of the
+// primary device and, when present, of the secondary device. The expiration
+// is informational, so a failed lookup is reported as a warning and the date
+// of the state is kept.
+func updateNetworkDeviceLicenseExpiration(fetchFunc getDeviceLicenseDetails, primary *ne.Device, secondary *ne.Device, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	fetch := func(uuid string) *networkDeviceLicenseDetails {
+		details, err := fetchFunc(uuid)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Could not fetch license expiration of network device %s", uuid),
+				Detail:   err.Error(),
+			})
+			return nil
+		}
+		return details
+	}
+	if details := fetch(ne.StringValue(primary.UUID)); details != nil {
+		if err := d.Set(neDeviceSchemaNames["LicenseExpiration"], details.LicenseExpirationDate); err != nil {
+			return append(diags, diag.Errorf("error reading LicenseExpiration: %s", err)...)
+		}
+	}
+	if secondary == nil {
+		return diags
+	}
+	secondaries, ok := d.Get(neDeviceSchemaNames["Secondary"]).([]interface{})
+	if !ok || len(secondaries) == 0 || secondaries[0] == nil {
+		return diags
+	}
+	expiration, _ := d.GetChange(neDeviceSchemaNames["Secondary"] + ".0." + neDeviceSchemaNames["LicenseExpiration"])
+	if details := fetch(ne.StringValue(secondary.UUID)); details != nil {
+		expiration = ne.StringValue(details.LicenseExpirationDate)
+	}
+	secondaries[0].(map[string]interface{})[neDeviceSchemaNames["LicenseExpiration"]] = expiration
+	if err := d.Set(neDeviceSchemaNames["Secondary"], secondaries); err != nil {
+		return append(diags, diag.Errorf("error reading Secondary: %s", err)...)
+	}
+	return diags
+}
@@ -0,0 +1,113 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDevice_licenseChange(t *testing.T) {
+	// given
+	secondaryPrefix := neDeviceSchemaNames["Secondary"] + ".0."
+	rd := mockedResourceDataProvider{
+		old: map[string]interface{}{
+			neDeviceSchemaNames["LicenseToken"]:                    "oldToken",
+			secondaryPrefix + neDeviceSchemaNames["LicenseFile"]:   "/tmp/old.lic",
+			secondaryPrefix + neDeviceSchemaNames["LicenseFileID"]: "oldFileID",
+		},
+		actual: map[string]interface{}{
+			neDeviceSchemaNames["LicenseToken"]:                    "newToken",
+			secondaryPrefix + neDeviceSchemaNames["LicenseFile"]:   "/tmp/new.lic",
+			secondaryPrefix + neDeviceSchemaNames["LicenseFileID"]: "oldFileID",
+		},
+	}
+	removed := mockedResourceDataProvider{
+		old: map[string]interface{}{
+			neDeviceSchemaNames["LicenseToken"]: "oldToken",
+		},
+		actual: map[string]interface{}{
+			neDeviceSchemaNames["LicenseToken"]: "",
+		},
+	}
+	// when
+	primary := getNetworkDeviceLicenseChange(rd, "")
+	secondary := getNetworkDeviceLicenseChange(rd, secondaryPrefix)
+	none := getNetworkDeviceLicenseChange(removed, "")
+	// then
+	assert.Equal(t, "newToken", ne.StringValue(primary.LicenseToken), "Changed license token is applied")
+	assert.Equal(t, "/tmp/new.lic", ne.StringValue(secondary.LicenseFile), "Changed license file is applied")
+	assert.Nil(t, secondary.LicenseFileID, "License file identifier is not applied with a license file")
+	assert.Nil(t, none, "Removed license is not applied")
+}
+
+func TestNetworkDevice_licenseUpdateWaitConfiguration(t *testing.T) {
+	// given
+	statuses := []string{ne.DeviceLicenseStateApplying, ne.DeviceLicenseStateRegistered}
+	calls := 0
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		status := statuses[calls]
+		calls++
+		return &ne.Device{LicenseStatus: ne.String(status)}, nil
+	}
+	initialDelay := 10 * time.Millisecond
+	// when
+	waitConfig := createNetworkDeviceLicenseUpdateWaitConfiguration(fetchFunc, "test", initialDelay, 10*time.Millisecond, time.Minute)
+	_, err := waitConfig.WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, 2, calls, "Device is fetched until license is registered")
+	assert.Equal(t, initialDelay, waitConfig.Delay, "License update wait configuration delay matches")
+}
+
+func TestNetworkDevice_updateLicenseExpiration(t *testing.T) {
+	// given
+	expirations := map[string]string{
+		"primary":   "2024-03-01T00:00:00Z",
+		"secondary": "2024-04-01T00:00:00Z",
+	}
+	fetchFunc := func(uuid string) (*networkDeviceLicenseDetails, error) {
+		return &networkDeviceLicenseDetails{LicenseExpirationDate: ne.String(expirations[uuid])}, nil
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), make(map[string]interface{}))
+	d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(&ne.Device{
+		Name:         ne.String("secondary"),
+		LicenseToken: ne.String("token"),
+	}))
+	// when
+	diags := updateNetworkDeviceLicenseExpiration(fetchFunc, &ne.Device{UUID: ne.String("primary")}, &ne.Device{UUID: ne.String("secondary")}, d)
+	// then
+	secondaryPrefix := neDeviceSchemaNames["Secondary"] + ".0."
+	assert.Empty(t, diags, "Update of license expiration does not return diagnostics")
+	assert.Equal(t, expirations["primary"], d.Get(neDeviceSchemaNames["LicenseExpiration"]), "Primary license expiration matches")
+	assert.Equal(t, expirations["secondary"], d.Get(secondaryPrefix+neDeviceSchemaNames["LicenseExpiration"]), "Secondary license expiration matches")
+	assert.Equal(t, "token", d.Get(secondaryPrefix+neDeviceSchemaNames["LicenseToken"]), "Secondary license token is kept")
+}
+
+func TestNetworkDevice_updateLicenseExpiration_fetchFailure(t *testing.T) {
+	// given
+	fetchFunc := func(uuid string) (*networkDeviceLicenseDetails, error) {
+		if uuid == "secondary" {
+			return nil, fmt.Errorf("service unavailable")
+		}
+		return &networkDeviceLicenseDetails{LicenseExpirationDate: ne.String("2024-03-01T00:00:00Z")}, nil
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), make(map[string]interface{}))
+	d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(&ne.Device{
+		Name: ne.String("secondary"),
+	}))
+	// when
+	diags := updateNetworkDeviceLicenseExpiration(fetchFunc, &ne.Device{UUID: ne.String("primary")}, &ne.Device{UUID: ne.String("secondary")}, d)
+	// then
+	assert.False(t, diags.HasError(), "Failed license lookup is not an error")
+	assert.Len(t, diags, 1, "Failed license lookup is reported")
+	assert.Equal(t, diag.Warning, diags[0].Severity, "Failed license lookup is a warning")
+	assert.Contains(t, diags[0].Summary, "secondary", "Warning names the device")
+	assert.Equal(t, "2024-03-01T00:00:00Z", d.Get(neDeviceSchemaNames["LicenseExpiration"]), "Primary license expiration is set")
+	assert.Equal(t, "secondary", d.Get(neDeviceSchemaNames["Secondary"]+".0."+neDeviceSchemaNames["Name"]), "Secondary device is kept")
+}
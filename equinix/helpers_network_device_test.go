@@ -0,0 +1,186 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDevice_checkSoftwareVersion(t *testing.T) {
+	// given
+	versions := []ne.DeviceSoftwareVersion{
+		{Version: ne.String("16.09.05"), PackageCodes: []string{"SEC", "APPX"}},
+		{Version: ne.String("17.03.01a"), PackageCodes: []string{"APPX"}},
+	}
+	// when
+	validErr := checkNetworkDeviceSoftwareVersion(versions, "CSR1000V", "SEC", "16.09.05")
+	packageErr := checkNetworkDeviceSoftwareVersion(versions, "CSR1000V", "SEC", "17.03.01a")
+	unknownErr := checkNetworkDeviceSoftwareVersion(versions, "CSR1000V", "SEC", "18.01")
+	// then
+	assert.Nil(t, validErr, "Available version is accepted")
+	assert.ErrorContains(t, packageErr, "does not support package")
+	assert.ErrorContains(t, unknownErr, "is not available")
+}
+
+func TestNetworkDevice_versionUpgradeWaitConfiguration(t *testing.T) {
+	// given
+	deviceID := "test"
+	calls := 0
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		calls++
+		if calls < 2 {
+			return &ne.Device{Status: ne.String(ne.DeviceStateProvisioned), Version: ne.String("16.09.05")}, nil
+		}
+		return &ne.Device{Status: ne.String(ne.DeviceStateProvisioned), Version: ne.String("17.03.01a")}, nil
+	}
+	failedFetchFunc := func(uuid string) (*ne.Device, error) {
+		return &ne.Device{Status: ne.String(ne.DeviceStateFailed)}, nil
+	}
+	delay := 100 * time.Millisecond
+	timeout := 10 * time.Minute
+	// when
+	waitConfig := createNetworkDeviceVersionUpgradeWaitConfiguration(fetchFunc, deviceID, "17.03.01a", delay, timeout)
+	_, err := waitConfig.WaitForStateContext(context.Background())
+	failedWaitConfig := createNetworkDeviceVersionUpgradeWaitConfiguration(failedFetchFunc, deviceID, "17.03.01a", delay, timeout)
+	_, failedErr := failedWaitConfig.WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, 2, calls, "Device is fetched until it has the new version")
+	assert.Error(t, failedErr, "WaitForState returns an error for a failed device")
+	assert.Equal(t, timeout, waitConfig.Timeout, "Device upgrade wait configuration timeout matches")
+	assert.Equal(t, delay, waitConfig.MinTimeout, "Device upgrade wait configuration min timeout matches")
+}
+
+func TestNetworkDevice_upgradeVersionOneAtATime(t *testing.T) {
+	// given
+	versions := map[string]string{"primary": "16.09.05", "secondary": "16.09.05"}
+	upgraded := []string{}
+	upgradeFunc := func(uuid string, request map[string]interface{}) error {
+		for _, id := range upgraded {
+			if versions[id] != request["version"] {
+				return fmt.Errorf("device %s is still upgrading", id)
+			}
+		}
+		assert.Equal(t, neDeviceUpgradeTypeSoftware, request["upgradeType"])
+		upgraded = append(upgraded, uuid)
+		versions[uuid] = request["version"].(string)
+		return nil
+	}
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		return &ne.Device{Status: ne.String(ne.DeviceStateProvisioned), Version: ne.String(versions[uuid])}, nil
+	}
+	// when
+	err := upgradeNetworkDeviceVersion(context.Background(), upgradeFunc, fetchFunc, []string{"secondary", "primary"}, "17.03.01a", time.Minute)
+	// then
+	assert.Nil(t, err, "Upgrade does not return an error")
+	assert.Equal(t, []string{"secondary", "primary"}, upgraded, "Devices are upgraded in order")
+	assert.Equal(t, "17.03.01a", versions["primary"])
+}
+
+func TestNetworkDevice_resizeWaitConfigurations(t *testing.T) {
+	// given
+	device := &ne.Device{
+		Status:         ne.String(ne.DeviceStateProvisioned),
+		CoreCount:      ne.Int(4),
+		PackageCode:    ne.String("SEC"),
+		Throughput:     ne.Int(1),
+		ThroughputUnit: ne.String("Gbps"),
+		InterfaceCount: ne.Int(10),
+	}
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		return device, nil
+	}
+	delay := 100 * time.Millisecond
+	timeout := time.Second
+	// when
+	_, coreErr := createNetworkDeviceCoreResizeWaitConfiguration(fetchFunc, "test", 4, delay, timeout).WaitForStateContext(context.Background())
+	_, packageErr := createNetworkDevicePackageResizeWaitConfiguration(fetchFunc, "test", "SEC", delay, timeout).WaitForStateContext(context.Background())
+	_, throughputErr := createNetworkDeviceThroughputResizeWaitConfiguration(fetchFunc, "test", 1, "Gbps", delay, timeout).WaitForStateContext(context.Background())
+	_, interfaceErr := createNetworkDeviceInterfaceResizeWaitConfiguration(fetchFunc, "test", 10, delay, timeout).WaitForStateContext(context.Background())
+	_, pendingErr := createNetworkDeviceThroughputResizeWaitConfiguration(fetchFunc, "test", 1000, "Mbps", delay, timeout).WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, coreErr, "Core count resize wait returns when device has new core count")
+	assert.Nil(t, packageErr, "Package resize wait returns when device has new package")
+	assert.Nil(t, throughputErr, "Throughput resize wait returns when device has new throughput")
+	assert.Nil(t, interfaceErr, "Interface count resize wait returns when device has new interface count")
+	assert.Error(t, pendingErr, "Throughput resize wait times out when device has old throughput unit")
+}
+
+func TestNetworkDevice_resizeInOrder(t *testing.T) {
+	// given
+	devices := map[string]*ne.Device{
+		"secondary": {Status: ne.String(ne.DeviceStateProvisioned)},
+		"primary":   {Status: ne.String(ne.DeviceStateProvisioned)},
+	}
+	requests := []string{}
+	upgradeFunc := func(uuid string, request map[string]interface{}) error {
+		device := devices[uuid]
+		switch request["upgradeType"] {
+		case neDeviceUpgradeTypeCore:
+			device.CoreCount = ne.Int(request["core"].(int))
+		case neDeviceUpgradeTypePackage:
+			device.PackageCode = ne.String(request["packageCode"].(string))
+		case neDeviceUpgradeTypeThroughput:
+			device.Throughput = ne.Int(request["throughput"].(int))
+			device.ThroughputUnit = ne.String(request["throughputUnit"].(string))
+		case neDeviceUpgradeTypeInterface:
+			device.InterfaceCount = ne.Int(request["interfaceCount"].(int))
+		}
+		requests = append(requests, fmt.Sprintf("%s:%s", uuid, request["upgradeType"]))
+		return nil
+	}
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		return devices[uuid], nil
+	}
+	changes := map[string]interface{}{
+		neDeviceSchemaNames["Throughput"]:     500,
+		neDeviceSchemaNames["ThroughputUnit"]: "Mbps",
+		neDeviceSchemaNames["CoreCount"]:      4,
+		neDeviceSchemaNames["PackageCode"]:    "SEC",
+		neDeviceSchemaNames["InterfaceCount"]: 24,
+	}
+	// when
+	err := resizeNetworkDevice(context.Background(), upgradeFunc, fetchFunc, []string{"secondary", "primary"}, changes, time.Minute)
+	// then
+	assert.Nil(t, err, "Resize does not return an error")
+	assert.Equal(t, []string{
+		"secondary:CORE", "primary:CORE",
+		"secondary:PACKAGE", "primary:PACKAGE",
+		"secondary:THROUGHPUT", "primary:THROUGHPUT",
+		"secondary:INTERFACE", "primary:INTERFACE",
+	}, requests, "Resizes are done one change and one device at a time")
+	assert.Equal(t, 500, ne.IntValue(devices["primary"].Throughput))
+	assert.Equal(t, 24, ne.IntValue(devices["primary"].InterfaceCount))
+}
+
+func TestNetworkDevice_checkPlatform(t *testing.T) {
+	// given
+	platforms := []ne.DevicePlatform{
+		{
+			CoreCount:       ne.Int(2),
+			PackageCodes:    []string{"IPBASE", "SEC"},
+			ManagementTypes: []string{ne.DeviceManagementTypeEquinix, ne.DeviceManagementTypeSelf},
+			LicenseOptions:  []string{ne.DeviceLicenseModeSubscription, ne.DeviceLicenseModeBYOL},
+		},
+		{
+			CoreCount:       ne.Int(4),
+			PackageCodes:    []string{"SEC"},
+			ManagementTypes: []string{ne.DeviceManagementTypeSelf},
+			LicenseOptions:  []string{ne.DeviceLicenseModeBYOL},
+		},
+	}
+	// when
+	validErr := checkNetworkDevicePlatform(platforms, "CSR1000V", 4, "SEC", ne.DeviceManagementTypeSelf, ne.DeviceLicenseModeBYOL)
+	packageErr := checkNetworkDevicePlatform(platforms, "CSR1000V", 4, "IPBASE", ne.DeviceManagementTypeSelf, ne.DeviceLicenseModeBYOL)
+	licenseErr := checkNetworkDevicePlatform(platforms, "CSR1000V", 4, "SEC", ne.DeviceManagementTypeSelf, ne.DeviceLicenseModeSubscription)
+	coreErr := checkNetworkDevicePlatform(platforms, "CSR1000V", 8, "SEC", ne.DeviceManagementTypeSelf, ne.DeviceLicenseModeBYOL)
+	// then
+	assert.Nil(t, validErr, "Supported platform is accepted")
+	assert.ErrorContains(t, packageErr, "has no platform")
+	assert.ErrorContains(t, licenseErr, "has no platform")
+	assert.ErrorContains(t, coreErr, "has no platform")
+}
@@ -0,0 +1,192 @@
+package equinix
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Vendor configuration of Network Edge devices is a loosely typed map which is
+// validated by the Network Edge API only while the device is provisioned.
+// Neither the API nor ne-go describe the vendor configuration of device types,
+// so the known requirements of SD-WAN device types are kept here and the
+// vendor configuration of these device types is limited to the known keys.
+
+const (
+	neDeviceVendorConfigFormatIPv4   = "IPV4"
+	neDeviceVendorConfigFormatSiteID = "SITE_ID"
+)
+
+type networkDeviceVendorConfigRequirement struct {
+	Key       string
+	Required  bool
+	Sensitive bool
+	Format    string
+}
+
+var neDeviceTypeVendorConfigRequirements = map[string][]networkDeviceVendorConfigRequirement{
+	"CSRSDWAN": {
+		{Key: "siteId", Required: true, Format: neDeviceVendorConfigFormatSiteID},
+		{Key: "systemIpAddress", Required: true, Format: neDeviceVendorConfigFormatIPv4},
+	},
+	"VERSA_SDWAN": {
+		{Key: "controller1", Required: true, Format: neDeviceVendorConfigFormatIPv4},
+		{Key: "controller2", Format: neDeviceVendorConfigFormatIPv4},
+		{Key: "localId", Required: true},
+		{Key: "remoteId", Required: true},
+		{Key: "serialNumber", Required: true},
+	},
+	"FG-SDWAN": {
+		{Key: "controller1", Required: true, Format: neDeviceVendorConfigFormatIPv4},
+		{Key: "adminPassword", Sensitive: true},
+		{Key: "activationKey", Sensitive: true},
+	},
+}
+
+// getNetworkDeviceVendorConfigRequirements returns the vendor configuration
+// requirements of the device type, or nil when the vendor configuration of the
+// device type is not validated.
+func getNetworkDeviceVendorConfigRequirements(typeCode string) []networkDeviceVendorConfigRequirement {
+	return neDeviceTypeVendorConfigRequirements[typeCode]
+}
+
+// checkNetworkDeviceVendorConfiguration checks that the vendor configuration
+// has all keys required by the device type, no keys which are not known for
+// the device type and values in the expected format. Values which are not
+// known yet are not checked.
+func checkNetworkDeviceVendorConfiguration(requirements []networkDeviceVendorConfigRequirement, typeCode string, config cty.Value) error {
+	if len(requirements) == 0 || config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	errs := &multierror.Error{}
+	values := config.AsValueMap()
+	known := make([]string, len(requirements))
+	for i, r := range requirements {
+		known[i] = r.Key
+		v, ok := values[r.Key]
+		if !ok || v.IsNull() {
+			if r.Required {
+				errs = multierror.Append(errs, fmt.Errorf("key %q is required by device type %q", r.Key, typeCode))
+			}
+			continue
+		}
+		if !v.IsKnown() {
+			continue
+		}
+		if err := checkNetworkDeviceVendorConfigFormat(r.Format, v.AsString()); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("key %q %s", r.Key, err))
+		}
+	}
+	for key := range values {
+		if !isStringInSlice(key, known) {
+			errs = multierror.Append(errs, fmt.Errorf("key %q is not known for device type %q, known keys are: %s", key, typeCode, strings.Join(known, ", ")))
+		}
+	}
+	sort.Sort(errs)
+	return errs.ErrorOrNil()
+}
+
+func checkNetworkDeviceVendorConfigFormat(format string, value string) error {
+	switch format {
+	case neDeviceVendorConfigFormatIPv4:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("has to be an IPv4 address, got %q", value)
+		}
+	case neDeviceVendorConfigFormatSiteID:
+		if id, err := strconv.ParseUint(value, 10, 32); err != nil || id == 0 {
+			return fmt.Errorf("has to be a number between 1 and 4294967295, got %q", value)
+		}
+	}
+	return nil
+}
+
+// checkNetworkDeviceVendorConfigurationValues checks the vendor configuration
+// together with the vendor secrets, which are sent to the API as one map, so
+// a key can't be set in both. Keys which are sensitive for the device type
+// have to be kept in the vendor secrets of new devices, while existing devices
+// which keep them in the vendor configuration are only warned about.
+func checkNetworkDeviceVendorConfigurationValues(requirements []networkDeviceVendorConfigRequirement, typeCode string, config cty.Value, secrets cty.Value, newDevice bool) error {
+	if !config.IsWhollyKnown() || !secrets.IsWhollyKnown() {
+		return nil
+	}
+	merged := make(map[string]cty.Value)
+	if !config.IsNull() {
+		for key, value := range config.AsValueMap() {
+			merged[key] = value
+		}
+	}
+	for _, r := range requirements {
+		if _, ok := merged[r.Key]; !ok || !r.Sensitive {
+			continue
+		}
+		if newDevice {
+			return fmt.Errorf("key %q is sensitive for device type %q and has to be set in %s", r.Key, typeCode, neDeviceSchemaNames["VendorSecrets"])
+		}
+		log.Printf("[WARN] vendor configuration key %q of device type %q is sensitive and should be set in %s", r.Key, typeCode, neDeviceSchemaNames["VendorSecrets"])
+	}
+	if !secrets.IsNull() {
+		for key, value := range secrets.AsValueMap() {
+			if _, ok := merged[key]; ok {
+				return fmt.Errorf("key %q can't be set in both %s and %s", key, neDeviceSchemaNames["VendorConfiguration"], neDeviceSchemaNames["VendorSecrets"])
+			}
+			merged[key] = value
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return checkNetworkDeviceVendorConfiguration(requirements, typeCode, cty.MapVal(merged))
+}
+
+// expandNetworkDeviceVendorConfiguration merges the vendor configuration and
+// the vendor secrets into the vendor configuration sent to the API.
+func expandNetworkDeviceVendorConfiguration(config interface{}, secrets interface{}) map[string]string {
+	transformed := make(map[string]string)
+	for _, v := range []interface{}{config, secrets} {
+		if m, ok := v.(map[string]interface{}); ok {
+			for key, value := range expandInterfaceMapToStringMap(m) {
+				transformed[key] = value
+			}
+		}
+	}
+	if len(transformed) == 0 {
+		return nil
+	}
+	return transformed
+}
+
+// splitNetworkDeviceVendorConfiguration splits the vendor configuration read
+// from the API into the vendor configuration and the vendor secrets. Keys
+// which are already kept in the vendor secrets stay there. When neither map
+// was set before, e.g. on import, keys which are sensitive for the device type
+// are put into the vendor secrets.
+func splitNetworkDeviceVendorConfiguration(requirements []networkDeviceVendorConfigRequirement, vendorConfig map[string]string, existingConfig interface{}, existingSecrets interface{}) (map[string]string, map[string]string) {
+	secretKeys := make(map[string]bool)
+	existingSecretsMap, _ := existingSecrets.(map[string]interface{})
+	for key := range existingSecretsMap {
+		secretKeys[key] = true
+	}
+	if existingConfigMap, _ := existingConfig.(map[string]interface{}); len(existingConfigMap) == 0 && len(existingSecretsMap) == 0 {
+		for _, r := range requirements {
+			if r.Sensitive {
+				secretKeys[r.Key] = true
+			}
+		}
+	}
+	config := make(map[string]string)
+	secrets := make(map[string]string)
+	for key, value := range vendorConfig {
+		if secretKeys[key] {
+			secrets[key] = value
+		} else {
+			config[key] = value
+		}
+	}
+	return config, secrets
+}
@@ -0,0 +1,115 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDevice_checkVendorConfiguration(t *testing.T) {
+	// given
+	requirements := getNetworkDeviceVendorConfigRequirements("CSRSDWAN")
+	valid := cty.MapVal(map[string]cty.Value{
+		"siteId":          cty.StringVal("10"),
+		"systemIpAddress": cty.StringVal("1.1.1.1"),
+	})
+	unknownValue := cty.MapVal(map[string]cty.Value{
+		"siteId":          cty.StringVal("10"),
+		"systemIpAddress": cty.UnknownVal(cty.String),
+	})
+	invalid := cty.MapVal(map[string]cty.Value{
+		"siteId":      cty.StringVal("0"),
+		"controller1": cty.StringVal("1.1.1.1"),
+	})
+	// when
+	validErr := checkNetworkDeviceVendorConfiguration(requirements, "CSRSDWAN", valid)
+	unknownValueErr := checkNetworkDeviceVendorConfiguration(requirements, "CSRSDWAN", unknownValue)
+	nullErr := checkNetworkDeviceVendorConfiguration(requirements, "CSRSDWAN", cty.NullVal(cty.Map(cty.String)))
+	notValidatedErr := checkNetworkDeviceVendorConfiguration(getNetworkDeviceVendorConfigRequirements("CSR1000V"), "CSR1000V", invalid)
+	invalidErr := checkNetworkDeviceVendorConfiguration(requirements, "CSRSDWAN", invalid)
+	// then
+	assert.Nil(t, validErr, "Valid vendor configuration does not return error")
+	assert.Nil(t, unknownValueErr, "Unknown values are not checked")
+	assert.Nil(t, nullErr, "Missing vendor configuration is not checked")
+	assert.Nil(t, notValidatedErr, "Vendor configuration of device type without requirements is not checked")
+	assert.NotNil(t, invalidErr, "Invalid vendor configuration returns error")
+	assert.Contains(t, invalidErr.Error(), `key "systemIpAddress" is required`, "Missing required key is reported")
+	assert.Contains(t, invalidErr.Error(), `key "controller1" is not known for device type "CSRSDWAN", known keys are: siteId, systemIpAddress`, "Unknown key is reported with known keys")
+	assert.Contains(t, invalidErr.Error(), `key "siteId" has to be a number`, "Invalid format is reported")
+}
+
+func TestNetworkDevice_checkVendorConfigFormat(t *testing.T) {
+	// when
+	ipv4Err := checkNetworkDeviceVendorConfigFormat(neDeviceVendorConfigFormatIPv4, "192.168.1.1")
+	ipv6Err := checkNetworkDeviceVendorConfigFormat(neDeviceVendorConfigFormatIPv4, "2001:db8::1")
+	siteIDErr := checkNetworkDeviceVendorConfigFormat(neDeviceVendorConfigFormatSiteID, "4294967295")
+	siteIDOverflowErr := checkNetworkDeviceVendorConfigFormat(neDeviceVendorConfigFormatSiteID, "4294967296")
+	freeFormErr := checkNetworkDeviceVendorConfigFormat("", "SDWAN-Branch@Versa.com")
+	// then
+	assert.Nil(t, ipv4Err, "IPv4 address is valid")
+	assert.NotNil(t, ipv6Err, "IPv6 address is not valid")
+	assert.Nil(t, siteIDErr, "Highest site identifier is valid")
+	assert.NotNil(t, siteIDOverflowErr, "Site identifier out of range is not valid")
+	assert.Nil(t, freeFormErr, "Value without format is not checked")
+}
+
+func TestNetworkDevice_checkVendorConfigurationValues(t *testing.T) {
+	// given
+	requirements := getNetworkDeviceVendorConfigRequirements("FG-SDWAN")
+	config := cty.MapVal(map[string]cty.Value{
+		"controller1": cty.StringVal("1.1.1.1"),
+	})
+	secrets := cty.MapVal(map[string]cty.Value{
+		"adminPassword": cty.StringVal("secret"),
+	})
+	duplicateSecrets := cty.MapVal(map[string]cty.Value{
+		"controller1": cty.StringVal("1.1.1.1"),
+	})
+	sensitiveConfig := cty.MapVal(map[string]cty.Value{
+		"controller1":   cty.StringVal("1.1.1.1"),
+		"adminPassword": cty.StringVal("secret"),
+	})
+	nullMap := cty.NullVal(cty.Map(cty.String))
+	// when
+	validErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", config, secrets, true)
+	duplicateErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", config, duplicateSecrets, true)
+	missingErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", nullMap, secrets, true)
+	emptyErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", nullMap, nullMap, true)
+	sensitiveErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", sensitiveConfig, nullMap, true)
+	existingSensitiveErr := checkNetworkDeviceVendorConfigurationValues(requirements, "FG-SDWAN", sensitiveConfig, nullMap, false)
+	// then
+	assert.Nil(t, validErr, "Vendor configuration with vendor secrets does not return error")
+	assert.EqualError(t, sensitiveErr, `key "adminPassword" is sensitive for device type "FG-SDWAN" and has to be set in sensitive_vendor_configuration`, "Sensitive key in vendor configuration of new device returns error")
+	assert.Nil(t, existingSensitiveErr, "Sensitive key in vendor configuration of existing device does not return error")
+	assert.ErrorContains(t, duplicateErr, `key "controller1" can't be set in both`, "Key set in both maps returns error")
+	assert.ErrorContains(t, missingErr, `key "controller1" is required`, "Vendor secrets are checked together with vendor configuration")
+	assert.Nil(t, emptyErr, "Missing vendor configuration is not checked")
+}
+
+func TestNetworkDevice_expandVendorConfiguration(t *testing.T) {
+	// given
+	config := map[string]interface{}{"controller1": "1.1.1.1"}
+	secrets := map[string]interface{}{"adminPassword": "secret"}
+	// when
+	result := expandNetworkDeviceVendorConfiguration(config, secrets)
+	empty := expandNetworkDeviceVendorConfiguration(map[string]interface{}{}, nil)
+	// then
+	assert.Equal(t, map[string]string{"controller1": "1.1.1.1", "adminPassword": "secret"}, result, "Vendor secrets are merged into vendor configuration")
+	assert.Nil(t, empty, "Empty vendor configuration is not sent")
+}
+
+func TestNetworkDevice_splitVendorConfiguration(t *testing.T) {
+	// given
+	requirements := getNetworkDeviceVendorConfigRequirements("FG-SDWAN")
+	vendorConfig := map[string]string{"controller1": "1.1.1.1", "adminPassword": "secret", "activationKey": "key"}
+	// when
+	config, secrets := splitNetworkDeviceVendorConfiguration(requirements, vendorConfig,
+		map[string]interface{}{"controller1": "1.1.1.1", "adminPassword": "secret"}, map[string]interface{}{"activationKey": "key"})
+	importedConfig, importedSecrets := splitNetworkDeviceVendorConfiguration(requirements, vendorConfig, nil, nil)
+	// then
+	assert.Equal(t, map[string]string{"controller1": "1.1.1.1", "adminPassword": "secret"}, config, "Keys stay in vendor configuration")
+	assert.Equal(t, map[string]string{"activationKey": "key"}, secrets, "Keys stay in vendor secrets")
+	assert.Equal(t, map[string]string{"controller1": "1.1.1.1"}, importedConfig, "Imported keys which are not sensitive are put into vendor configuration")
+	assert.Equal(t, map[string]string{"adminPassword": "secret", "activationKey": "key"}, importedSecrets, "Imported sensitive keys are put into vendor secrets")
+}
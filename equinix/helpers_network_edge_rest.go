@@ -0,0 +1,62 @@
+package equinix
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/equinix/ne-go"
+	"github.com/equinix/rest-go"
+)
+
+// Some Network Edge APIs are not covered by ne-go: device upgrades, license
+// updates, backups and interfaces, BGP peering options and outbound rules of
+// ACL templates. They are called with the REST client of the ne-go client, so
+// that requests share its authentication, user agent and error handling. All
+// raw REST requests go through the functions below.
+
+func neRestClient(c ne.Client) (*ne.RestClient, error) {
+	rc, ok := c.(*ne.RestClient)
+	if !ok {
+		return nil, fmt.Errorf("network edge client of type %T does not support REST requests", c)
+	}
+	return rc, nil
+}
+
+// nePath joins the escaped path segments to the base path of a Network Edge
+// API resource.
+func nePath(base string, segments ...string) string {
+	escaped := make([]string, len(segments)+1)
+	escaped[0] = base
+	for i := range segments {
+		escaped[i+1] = url.PathEscape(segments[i])
+	}
+	return strings.Join(escaped, "/")
+}
+
+// neRestExecute sends a request with the body, when not nil, and decodes the
+// response into the result, when not nil.
+func neRestExecute(c ne.Client, method, path string, body, result interface{}) error {
+	rc, err := neRestClient(c)
+	if err != nil {
+		return err
+	}
+	req := rc.R()
+	if body != nil {
+		req.SetBody(body)
+	}
+	if result != nil {
+		req.SetResult(result)
+	}
+	return rc.Execute(req, method, path)
+}
+
+// neRestGetOffsetPaginated fetches all the pages of an offset paginated
+// Network Edge API resource.
+func neRestGetOffsetPaginated(c ne.Client, path string, result interface{}, params map[string]string) ([]interface{}, error) {
+	rc, err := neRestClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return rc.GetOffsetPaginated(path, result, rest.DefaultOffsetPagingConfig().SetAdditionalParams(params))
+}
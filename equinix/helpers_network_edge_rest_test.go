@@ -0,0 +1,64 @@
+package equinix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkEdgeREST_path(t *testing.T) {
+	// given
+	base := "/ne/v1/devices"
+	uuid := "a/b c"
+	// when
+	path := nePath(base, uuid, "upgrade")
+	// then
+	assert.Equal(t, "/ne/v1/devices/a%2Fb%20c/upgrade", path, "Path segments are escaped")
+}
+
+func TestNetworkEdgeREST_deviceUpgrader(t *testing.T) {
+	// given
+	var method, path string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := ne.NewClient(context.Background(), server.URL, server.Client())
+	request := map[string]interface{}{
+		"upgradeType": neDeviceUpgradeTypeCore,
+		"core":        float64(4),
+	}
+	// when
+	err := neDeviceUpgrader(client)("device-uuid", request)
+	// then
+	assert.Nil(t, err, "Upgrade request succeeds")
+	assert.Equal(t, http.MethodPost, method, "Upgrade is requested with POST")
+	assert.Equal(t, "/ne/v1/devices/device-uuid/upgrade", path, "Upgrade is requested on the device")
+	assert.Equal(t, request, body, "Upgrade request body matches")
+}
+
+func TestNetworkEdgeREST_executeError(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`[{"errorCode":"IC-LAYER2-4021","errorMessage":"invalid core count"}]`))
+	}))
+	defer server.Close()
+	client := ne.NewClient(context.Background(), server.URL, server.Client())
+	// when
+	err := neRestExecute(client, http.MethodPost, nePath("/ne/v1/devices", "device-uuid", "upgrade"), map[string]interface{}{}, nil)
+	// then
+	assert.NotNil(t, err, "Failed request returns an error")
+}
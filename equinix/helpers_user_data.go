@@ -0,0 +1,160 @@
+package equinix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// userDataMaxSize is the largest user_data or custom_data accepted at plan
+// time, larger payloads fail only when the device is provisioned.
+const userDataMaxSize = 64 * 1024
+
+const (
+	userDataFormatCloudConfig = "cloud-config"
+	userDataFormatShellScript = "shell-script"
+	userDataFormatIPXE        = "ipxe"
+	userDataFormatMultipart   = "multipart"
+	userDataFormatGzip        = "gzip"
+	userDataFormatIgnition    = "ignition"
+	userDataFormatUnknown     = "unknown"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// detectUserDataFormat tells the format of the user data the same way
+// cloud-init does, by its first line. iPXE scripts are checked before shell
+// scripts as both start with a shebang.
+func detectUserDataFormat(userData string) string {
+	switch {
+	case strings.HasPrefix(userData, "#cloud-config"):
+		return userDataFormatCloudConfig
+	case matchIPXEScript.MatchString(userData):
+		return userDataFormatIPXE
+	case strings.HasPrefix(userData, "#!"):
+		return userDataFormatShellScript
+	case strings.HasPrefix(userData, "Content-Type: multipart/"),
+		strings.HasPrefix(userData, "MIME-Version:"):
+		return userDataFormatMultipart
+	case bytes.HasPrefix([]byte(userData), gzipMagic):
+		return userDataFormatGzip
+	case strings.HasPrefix(strings.TrimSpace(userData), "{"):
+		// Flatcar and Fedora CoreOS boot with an Ignition config
+		return userDataFormatIgnition
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(userData)); err == nil && bytes.HasPrefix(decoded, gzipMagic) {
+		return userDataFormatGzip
+	}
+	return userDataFormatUnknown
+}
+
+// checkUserData checks the syntax of the user data in the detected format.
+// Unrecognized user data is passed to the device as is, so it only results
+// in a warning.
+func checkUserData(userData string) (warnings []string, err error) {
+	if userData == "" {
+		return nil, nil
+	}
+	switch detectUserDataFormat(userData) {
+	case userDataFormatCloudConfig:
+		return nil, checkCloudConfig(userData)
+	case userDataFormatMultipart:
+		return nil, checkMultipartUserData(userData)
+	case userDataFormatIgnition:
+		if !json.Valid([]byte(userData)) {
+			return nil, fmt.Errorf("invalid Ignition config, it is not valid JSON")
+		}
+	case userDataFormatUnknown:
+		return []string{"user data format not recognized, expected #cloud-config, a #! script, a MIME multipart archive or an Ignition config"}, nil
+	}
+	return nil, nil
+}
+
+func checkCloudConfig(cloudConfig string) error {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(cloudConfig), &doc); err != nil {
+		return fmt.Errorf("invalid cloud-config: %s", err)
+	}
+	return nil
+}
+
+func checkMultipartUserData(userData string) error {
+	tp := textproto.NewReader(bufio.NewReader(strings.NewReader(userData)))
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return fmt.Errorf("invalid multipart user data header: %s", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("invalid multipart user data Content-Type: %s", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return fmt.Errorf("multipart user data needs a multipart Content-Type with a boundary, got %q", header.Get("Content-Type"))
+	}
+
+	mr := multipart.NewReader(tp.R, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			if i == 0 {
+				return fmt.Errorf("multipart user data has no parts")
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid multipart user data part %d: %s", i, err)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return fmt.Errorf("invalid multipart user data part %d: %s", i, err)
+		}
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			continue
+		}
+		ct, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err := checkUserDataPart(ct, string(content)); err != nil {
+			return fmt.Errorf("part %d: %s", i, err)
+		}
+	}
+}
+
+// validateUserData is the plan time check of the device user_data.
+func validateUserData(v interface{}, k string) (ws []string, errs []error) {
+	userData := v.(string)
+	if len(userData) > userDataMaxSize {
+		return nil, []error{fmt.Errorf("%q is %d bytes, the limit is %d bytes", k, len(userData), userDataMaxSize)}
+	}
+	warnings, err := checkUserData(userData)
+	for _, w := range warnings {
+		ws = append(ws, fmt.Sprintf("%q: %s", k, w))
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q: %s", k, err))
+	}
+	return ws, errs
+}
+
+// validateCustomData is the plan time check of the device custom_data, which
+// is checked to be valid JSON when it looks like JSON.
+func validateCustomData(v interface{}, k string) (ws []string, errs []error) {
+	customData := v.(string)
+	if len(customData) > userDataMaxSize {
+		return nil, []error{fmt.Errorf("%q is %d bytes, the limit is %d bytes", k, len(customData), userDataMaxSize)}
+	}
+	trimmed := strings.TrimSpace(customData)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if !json.Valid([]byte(trimmed)) {
+			errs = append(errs, fmt.Errorf("%q is not valid JSON", k))
+		}
+	}
+	return ws, errs
+}
@@ -0,0 +1,95 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_detectUserDataFormat(t *testing.T) {
+	// given
+	tests := map[string]string{
+		"#cloud-config\npackages: [vim]\n":                         userDataFormatCloudConfig,
+		"#!/bin/bash\necho hello\n":                                userDataFormatShellScript,
+		"#!ipxe\nchain http://example.com/boot.ipxe\n":             userDataFormatIPXE,
+		"Content-Type: multipart/mixed; boundary=\"B\"\r\n\r\n":    userDataFormatMultipart,
+		"H4sIAAAAAAAA/0rOyS9N0c3MS8kvLtHLSy0tAAQAAP//tAnuXRIAAAA=": userDataFormatGzip,
+		`{"ignition": {"version": "3.3.0"}}`:                       userDataFormatIgnition,
+		"hello world":                                              userDataFormatUnknown,
+	}
+	for userData, expected := range tests {
+		// when
+		format := detectUserDataFormat(userData)
+		// then
+		assert.Equal(t, expected, format, userData)
+	}
+}
+
+func Test_validateUserData(t *testing.T) {
+	// given
+	valid := "#cloud-config\npackages:\n  - vim\n"
+	invalid := "#cloud-config\npackages:\n  - vim\n - emacs\n"
+	unknown := "hello world"
+	// when
+	_, validErrs := validateUserData(valid, "user_data")
+	_, invalidErrs := validateUserData(invalid, "user_data")
+	unknownWarns, unknownErrs := validateUserData(unknown, "user_data")
+	// then
+	assert.Empty(t, validErrs)
+	assert.Len(t, invalidErrs, 1)
+	assert.Len(t, unknownWarns, 1)
+	assert.Empty(t, unknownErrs)
+}
+
+func Test_validateUserData_multipart(t *testing.T) {
+	// given
+	parts := []interface{}{
+		map[string]interface{}{
+			"content":      "#cloud-config\npackages:\n  - vim\n",
+			"content_type": "text/cloud-config",
+			"filename":     "init.cfg",
+			"merge_type":   "",
+		},
+		map[string]interface{}{
+			"content":      "#!/bin/sh\necho hello\n",
+			"content_type": "text/x-shellscript",
+			"filename":     "",
+			"merge_type":   "",
+		},
+	}
+	// when
+	rendered, renderErr := renderMultipartUserData(parts, "MIMEBOUNDARY")
+	_, errs := validateUserData(rendered, "user_data")
+	// then
+	assert.Nil(t, renderErr)
+	assert.Equal(t, userDataFormatMultipart, detectUserDataFormat(rendered))
+	assert.Empty(t, errs)
+	assert.Contains(t, rendered, "filename=\"init.cfg\"")
+}
+
+func Test_renderMultipartUserData_invalidPart(t *testing.T) {
+	// given
+	parts := []interface{}{
+		map[string]interface{}{
+			"content":      "echo hello\n",
+			"content_type": "text/x-shellscript",
+			"filename":     "",
+			"merge_type":   "",
+		},
+	}
+	// when
+	_, err := renderMultipartUserData(parts, "MIMEBOUNDARY")
+	// then
+	assert.Error(t, err)
+}
+
+func Test_validateCustomData(t *testing.T) {
+	// when
+	_, validErrs := validateCustomData(`{"foo": "bar"}`, "custom_data")
+	_, invalidErrs := validateCustomData(`{"foo": }`, "custom_data")
+	_, plainErrs := validateCustomData("plain text", "custom_data")
+	// then
+	assert.Empty(t, validErrs)
+	assert.Len(t, invalidErrs, 1)
+	assert.Empty(t, plainErrs)
+}
@@ -0,0 +1,155 @@
+package equinix
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/packethost/packngo"
+)
+
+// defaultPortVlanBatchWindow is how long VLAN assignments to a port are
+// gathered before they are sent as a single batch.
+const defaultPortVlanBatchWindow = 3 * time.Second
+
+// portVlanAssignFunc assigns the VLANs to the port and returns the errors of
+// the VLANs that could not be assigned, keyed by VLAN ID.
+type portVlanAssignFunc func(client *packngo.Client, portID string, vlanIDs []string, timeout time.Duration) map[string]error
+
+// PortVlanBatcher coalesces concurrent VLAN assignments to the same port. The
+// first assignment to a port opens a short window, all assignments to the
+// port that arrive within the window are sent together, and every caller
+// gets the result of its own VLAN.
+//
+// The use case is equinix_metal_port_vlan_attachment, where each attachment
+// would otherwise assign its VLAN in a separate, serialized API call.
+type PortVlanBatcher struct {
+	lock    sync.Mutex
+	window  time.Duration
+	assign  portVlanAssignFunc
+	pending map[string]*portVlanBatch
+}
+
+type portVlanBatch struct {
+	client  *packngo.Client
+	timeout time.Duration
+	results map[string][]chan error
+}
+
+// Assign queues the VLAN for assignment to the port and blocks until the
+// batch it was sent with has been processed.
+func (b *PortVlanBatcher) Assign(client *packngo.Client, portID, vlanID string, timeout time.Duration) error {
+	result := make(chan error, 1)
+
+	b.lock.Lock()
+	batch, ok := b.pending[portID]
+	if !ok {
+		batch = &portVlanBatch{
+			client:  client,
+			results: map[string][]chan error{},
+		}
+		b.pending[portID] = batch
+		time.AfterFunc(b.window, func() { b.flush(portID) })
+	}
+	if timeout > batch.timeout {
+		batch.timeout = timeout
+	}
+	batch.results[vlanID] = append(batch.results[vlanID], result)
+	b.lock.Unlock()
+
+	return <-result
+}
+
+func (b *PortVlanBatcher) flush(portID string) {
+	b.lock.Lock()
+	batch := b.pending[portID]
+	delete(b.pending, portID)
+	b.lock.Unlock()
+
+	vlanIDs := make([]string, 0, len(batch.results))
+	for v := range batch.results {
+		vlanIDs = append(vlanIDs, v)
+	}
+	sort.Strings(vlanIDs)
+
+	log.Printf("[DEBUG] Assigning %d VLAN(s) to port %s in one batch", len(vlanIDs), portID)
+	errs := b.assign(batch.client, portID, vlanIDs, batch.timeout)
+	for v, results := range batch.results {
+		for _, r := range results {
+			r <- errs[v]
+		}
+	}
+}
+
+// Returns a properly initialized PortVlanBatcher
+func NewPortVlanBatcher(window time.Duration, assign portVlanAssignFunc) *PortVlanBatcher {
+	return &PortVlanBatcher{
+		window:  window,
+		assign:  assign,
+		pending: make(map[string]*portVlanBatch),
+	}
+}
+
+// assignPortVlans assigns the VLANs to the port with a VLAN assignment batch.
+// If the batch fails, the VLANs which were not assigned are retried one at a
+// time so that the error is reported for the VLAN which caused it.
+func assignPortVlans(client *packngo.Client, portID string, vlanIDs []string, timeout time.Duration) map[string]error {
+	errs := map[string]error{}
+	setAll := func(err error) map[string]error {
+		for _, v := range vlanIDs {
+			errs[v] = err
+		}
+		return errs
+	}
+
+	// Equinix Metal doesn't allow multiple VLAN assignment changes
+	// to the same port at the same time
+	lockId := "vlan-attachment-" + portID
+	metalMutexKV.Lock(lockId)
+	defer metalMutexKV.Unlock(lockId)
+
+	getOpts := &packngo.GetOptions{Includes: []string{"virtual_networks"}}
+	port, _, err := client.Ports.Get(portID, getOpts)
+	if err != nil {
+		return setAll(friendlyError(err))
+	}
+
+	vlansToAssign := difference(vlanIDs, attachedVlanIds(port))
+	if len(vlansToAssign) == 0 {
+		return errs
+	}
+
+	vacr := &packngo.VLANAssignmentBatchCreateRequest{}
+	for _, v := range vlansToAssign {
+		vacr.VLANAssignments = append(vacr.VLANAssignments, packngo.VLANAssignmentCreateRequest{
+			VLAN:  v,
+			State: packngo.VLANAssignmentAssigned,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cpr := &ClientPortResource{Client: client, Port: port}
+	err = createAndWaitForBatch(ctx, time.Now(), cpr, vacr)
+	if err == nil {
+		return errs
+	}
+	if len(vlansToAssign) == 1 {
+		errs[vlansToAssign[0]] = err
+		return errs
+	}
+
+	log.Printf("[WARN] VLAN assignment batch for port %s failed, assigning VLANs one at a time: %s", portID, err)
+	if port, _, err = client.Ports.Get(portID, getOpts); err != nil {
+		return setAll(friendlyError(err))
+	}
+	for _, v := range difference(vlansToAssign, attachedVlanIds(port)) {
+		par := &packngo.PortAssignRequest{PortID: portID, VirtualNetworkID: v}
+		if _, _, err := client.DevicePorts.Assign(par); err != nil {
+			errs[v] = err
+		}
+	}
+	return errs
+}
@@ -0,0 +1,66 @@
+package equinix
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortVlanBatcher_coalescesAssignments(t *testing.T) {
+	// given
+	var calls [][]string
+	var callsMutex sync.Mutex
+	assign := func(client *packngo.Client, portID string, vlanIDs []string, timeout time.Duration) map[string]error {
+		callsMutex.Lock()
+		defer callsMutex.Unlock()
+		calls = append(calls, vlanIDs)
+		return map[string]error{"vlan-2": fmt.Errorf("vlan-2 failed")}
+	}
+	batcher := NewPortVlanBatcher(50*time.Millisecond, assign)
+	vlans := []string{"vlan-1", "vlan-2", "vlan-3"}
+	errs := make([]error, len(vlans))
+	// when
+	wg := sync.WaitGroup{}
+	for i, v := range vlans {
+		wg.Add(1)
+		go func(i int, v string) {
+			defer wg.Done()
+			errs[i] = batcher.Assign(nil, "port-1", v, time.Minute)
+		}(i, v)
+	}
+	wg.Wait()
+	// then
+	assert.Equal(t, [][]string{vlans}, calls, "VLANs are assigned in a single call")
+	assert.Nil(t, errs[0], "vlan-1 assignment error")
+	assert.EqualError(t, errs[1], "vlan-2 failed", "vlan-2 assignment error")
+	assert.Nil(t, errs[2], "vlan-3 assignment error")
+}
+
+func TestPortVlanBatcher_separatesPorts(t *testing.T) {
+	// given
+	calls := map[string][]string{}
+	var callsMutex sync.Mutex
+	assign := func(client *packngo.Client, portID string, vlanIDs []string, timeout time.Duration) map[string]error {
+		callsMutex.Lock()
+		defer callsMutex.Unlock()
+		calls[portID] = append(calls[portID], vlanIDs...)
+		return nil
+	}
+	batcher := NewPortVlanBatcher(50*time.Millisecond, assign)
+	// when
+	wg := sync.WaitGroup{}
+	for _, p := range []string{"port-1", "port-2"} {
+		wg.Add(1)
+		go func(p string) {
+			defer wg.Done()
+			assert.Nil(t, batcher.Assign(nil, p, "vlan-"+p, time.Minute))
+		}(p)
+	}
+	wg.Wait()
+	// then
+	assert.Equal(t, map[string][]string{"port-1": {"vlan-port-1"}, "port-2": {"vlan-port-2"}}, calls)
+}
@@ -19,6 +19,7 @@ import (
 
 var (
 	metalMutexKV         = NewMutexKV()
+	metalPortVlanBatcher = NewPortVlanBatcher(defaultPortVlanBatchWindow, assignPortVlans)
 	DeviceNetworkTypes   = []string{"layer3", "hybrid", "layer2-individual", "layer2-bonded"}
 	DeviceNetworkTypesHB = []string{"layer3", "hybrid", "hybrid-bonded", "layer2-individual", "layer2-bonded"}
 	NetworkTypeList      = strings.Join(DeviceNetworkTypes, ", ")
@@ -103,79 +104,101 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"equinix_ecx_port":                   dataSourceECXPort(),
-			"equinix_ecx_l2_sellerprofile":       dataSourceECXL2SellerProfile(),
-			"equinix_ecx_l2_sellerprofiles":      dataSourceECXL2SellerProfiles(),
-			"equinix_fabric_routing_protocol":    dataSourceRoutingProtocol(),
-			"equinix_fabric_connection":          dataSourceFabricConnection(),
-			"equinix_fabric_cloud_router":        dataSourceCloudRouter(),
-			"equinix_fabric_port":                dataSourceFabricPort(),
-			"equinix_fabric_ports":               dataSourceFabricGetPortsByName(),
-			"equinix_fabric_service_profile":     dataSourceFabricServiceProfileReadByUuid(),
-			"equinix_fabric_service_profiles":    dataSourceFabricSearchServiceProfilesByName(),
-			"equinix_network_account":            dataSourceNetworkAccount(),
-			"equinix_network_device":             dataSourceNetworkDevice(),
-			"equinix_network_device_type":        dataSourceNetworkDeviceType(),
-			"equinix_network_device_software":    dataSourceNetworkDeviceSoftware(),
-			"equinix_network_device_platform":    dataSourceNetworkDevicePlatform(),
-			"equinix_metal_hardware_reservation": dataSourceMetalHardwareReservation(),
-			"equinix_metal_metro":                dataSourceMetalMetro(),
-			"equinix_metal_facility":             dataSourceMetalFacility(),
-			"equinix_metal_connection":           dataSourceMetalConnection(),
-			"equinix_metal_gateway":              dataSourceMetalGateway(),
-			"equinix_metal_ip_block_ranges":      dataSourceMetalIPBlockRanges(),
-			"equinix_metal_precreated_ip_block":  dataSourceMetalPreCreatedIPBlock(),
-			"equinix_metal_operating_system":     dataSourceOperatingSystem(),
-			"equinix_metal_organization":         dataSourceMetalOrganization(),
-			"equinix_metal_spot_market_price":    dataSourceSpotMarketPrice(),
-			"equinix_metal_device":               dataSourceMetalDevice(),
-			"equinix_metal_devices":              dataSourceMetalDevices(),
-			"equinix_metal_device_bgp_neighbors": dataSourceMetalDeviceBGPNeighbors(),
-			"equinix_metal_plans":                dataSourceMetalPlans(),
-			"equinix_metal_port":                 dataSourceMetalPort(),
-			"equinix_metal_project":              dataSourceMetalProject(),
-			"equinix_metal_project_ssh_key":      dataSourceMetalProjectSSHKey(),
-			"equinix_metal_reserved_ip_block":    dataSourceMetalReservedIPBlock(),
-			"equinix_metal_spot_market_request":  dataSourceMetalSpotMarketRequest(),
-			"equinix_metal_virtual_circuit":      dataSourceMetalVirtualCircuit(),
-			"equinix_metal_vlan":                 dataSourceMetalVlan(),
-			"equinix_metal_vrf":                  dataSourceMetalVRF(),
+			"equinix_ecx_port":                          dataSourceECXPort(),
+			"equinix_ecx_l2_sellerprofile":              dataSourceECXL2SellerProfile(),
+			"equinix_ecx_l2_sellerprofiles":             dataSourceECXL2SellerProfiles(),
+			"equinix_fabric_routing_protocol":           dataSourceRoutingProtocol(),
+			"equinix_fabric_connection":                 dataSourceFabricConnection(),
+			"equinix_fabric_cloud_router":               dataSourceCloudRouter(),
+			"equinix_fabric_port":                       dataSourceFabricPort(),
+			"equinix_fabric_ports":                      dataSourceFabricGetPortsByName(),
+			"equinix_fabric_service_profile":            dataSourceFabricServiceProfileReadByUuid(),
+			"equinix_fabric_service_profiles":           dataSourceFabricSearchServiceProfilesByName(),
+			"equinix_network_account":                   dataSourceNetworkAccount(),
+			"equinix_network_bgp":                       dataSourceNetworkBGP(),
+			"equinix_network_device":                    dataSourceNetworkDevice(),
+			"equinix_network_device_type":               dataSourceNetworkDeviceType(),
+			"equinix_network_device_software":           dataSourceNetworkDeviceSoftware(),
+			"equinix_network_device_platform":           dataSourceNetworkDevicePlatform(),
+			"equinix_network_device_backups":            dataSourceNetworkDeviceBackups(),
+			"equinix_network_device_interfaces":         dataSourceNetworkDeviceInterfaces(),
+			"equinix_network_devices":                   dataSourceNetworkDevices(),
+			"equinix_metal_hardware_reservation":        dataSourceMetalHardwareReservation(),
+			"equinix_metal_hardware_reservations":       dataSourceMetalHardwareReservations(),
+			"equinix_metal_metro":                       dataSourceMetalMetro(),
+			"equinix_metal_facility":                    dataSourceMetalFacility(),
+			"equinix_metal_capacity":                    dataSourceMetalCapacity(),
+			"equinix_metal_connection":                  dataSourceMetalConnection(),
+			"equinix_metal_connection_ports":            dataSourceMetalConnectionPorts(),
+			"equinix_metal_connection_virtual_circuits": dataSourceMetalConnectionVirtualCircuits(),
+			"equinix_metal_gateway":                     dataSourceMetalGateway(),
+			"equinix_metal_ip_block_ranges":             dataSourceMetalIPBlockRanges(),
+			"equinix_metal_precreated_ip_block":         dataSourceMetalPreCreatedIPBlock(),
+			"equinix_metal_operating_system":            dataSourceOperatingSystem(),
+			"equinix_metal_organization":                dataSourceMetalOrganization(),
+			"equinix_metal_organization_members":        dataSourceMetalOrganizationMembers(),
+			"equinix_metal_organization_invoices":       dataSourceMetalOrganizationInvoices(),
+			"equinix_metal_spot_market_price":           dataSourceSpotMarketPrice(),
+			"equinix_metal_device":                      dataSourceMetalDevice(),
+			"equinix_metal_devices":                     dataSourceMetalDevices(),
+			"equinix_metal_device_bgp_neighbors":        dataSourceMetalDeviceBGPNeighbors(),
+			"equinix_metal_plans":                       dataSourceMetalPlans(),
+			"equinix_metal_port":                        dataSourceMetalPort(),
+			"equinix_metal_project":                     dataSourceMetalProject(),
+			"equinix_metal_project_ssh_key":             dataSourceMetalProjectSSHKey(),
+			"equinix_metal_project_usage":               dataSourceMetalProjectUsage(),
+			"equinix_metal_project_bgp_sessions":        dataSourceMetalProjectBGPSessions(),
+			"equinix_metal_reserved_ip_block":           dataSourceMetalReservedIPBlock(),
+			"equinix_metal_spot_market_request":         dataSourceMetalSpotMarketRequest(),
+			"equinix_metal_virtual_circuit":             dataSourceMetalVirtualCircuit(),
+			"equinix_metal_user_data":                   dataSourceMetalUserData(),
+			"equinix_metal_vlan":                        dataSourceMetalVlan(),
+			"equinix_metal_vrf":                         dataSourceMetalVRF(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"equinix_ecx_l2_connection":          resourceECXL2Connection(),
-			"equinix_ecx_l2_connection_accepter": resourceECXL2ConnectionAccepter(),
-			"equinix_ecx_l2_serviceprofile":      resourceECXL2ServiceProfile(),
-			"equinix_fabric_cloud_router":        resourceCloudRouter(),
-			"equinix_fabric_connection":          resourceFabricConnection(),
-			"equinix_fabric_routing_protocol":    resourceFabricRoutingProtocol(),
-			"equinix_fabric_service_profile":     resourceFabricServiceProfile(),
-			"equinix_network_device":             resourceNetworkDevice(),
-			"equinix_network_ssh_user":           resourceNetworkSSHUser(),
-			"equinix_network_bgp":                resourceNetworkBGP(),
-			"equinix_network_ssh_key":            resourceNetworkSSHKey(),
-			"equinix_network_acl_template":       resourceNetworkACLTemplate(),
-			"equinix_network_device_link":        resourceNetworkDeviceLink(),
-			"equinix_network_file":               resourceNetworkFile(),
-			"equinix_metal_user_api_key":         resourceMetalUserAPIKey(),
-			"equinix_metal_project_api_key":      resourceMetalProjectAPIKey(),
-			"equinix_metal_connection":           resourceMetalConnection(),
-			"equinix_metal_device":               resourceMetalDevice(),
-			"equinix_metal_device_network_type":  resourceMetalDeviceNetworkType(),
-			"equinix_metal_ssh_key":              resourceMetalSSHKey(),
-			"equinix_metal_organization_member":  resourceMetalOrganizationMember(),
-			"equinix_metal_port":                 resourceMetalPort(),
-			"equinix_metal_project_ssh_key":      resourceMetalProjectSSHKey(),
-			"equinix_metal_project":              resourceMetalProject(),
-			"equinix_metal_organization":         resourceMetalOrganization(),
-			"equinix_metal_reserved_ip_block":    resourceMetalReservedIPBlock(),
-			"equinix_metal_ip_attachment":        resourceMetalIPAttachment(),
-			"equinix_metal_spot_market_request":  resourceMetalSpotMarketRequest(),
-			"equinix_metal_vlan":                 resourceMetalVlan(),
-			"equinix_metal_virtual_circuit":      resourceMetalVirtualCircuit(),
-			"equinix_metal_vrf":                  resourceMetalVRF(),
-			"equinix_metal_bgp_session":          resourceMetalBGPSession(),
-			"equinix_metal_port_vlan_attachment": resourceMetalPortVlanAttachment(),
-			"equinix_metal_gateway":              resourceMetalGateway(),
+			"equinix_ecx_l2_connection":              resourceECXL2Connection(),
+			"equinix_ecx_l2_connection_accepter":     resourceECXL2ConnectionAccepter(),
+			"equinix_ecx_l2_serviceprofile":          resourceECXL2ServiceProfile(),
+			"equinix_fabric_cloud_router":            resourceCloudRouter(),
+			"equinix_fabric_connection":              resourceFabricConnection(),
+			"equinix_fabric_routing_protocol":        resourceFabricRoutingProtocol(),
+			"equinix_fabric_service_profile":         resourceFabricServiceProfile(),
+			"equinix_network_device":                 resourceNetworkDevice(),
+			"equinix_network_ssh_user":               resourceNetworkSSHUser(),
+			"equinix_network_bgp":                    resourceNetworkBGP(),
+			"equinix_network_ssh_key":                resourceNetworkSSHKey(),
+			"equinix_network_acl_template":           resourceNetworkACLTemplate(),
+			"equinix_network_device_link":            resourceNetworkDeviceLink(),
+			"equinix_network_device_backup":          resourceNetworkDeviceBackup(),
+			"equinix_network_device_backup_restore":  resourceNetworkDeviceBackupRestore(),
+			"equinix_network_file":                   resourceNetworkFile(),
+			"equinix_metal_user_api_key":             resourceMetalUserAPIKey(),
+			"equinix_metal_project_api_key":          resourceMetalProjectAPIKey(),
+			"equinix_metal_connection":               resourceMetalConnection(),
+			"equinix_metal_elastic_ip_assignment":    resourceMetalElasticIPAssignment(),
+			"equinix_metal_hardware_reservation":     resourceMetalHardwareReservation(),
+			"equinix_metal_service_token_redemption": resourceMetalServiceTokenRedemption(),
+			"equinix_metal_device":                   resourceMetalDevice(),
+			"equinix_metal_device_batch":             resourceMetalDeviceBatch(),
+			"equinix_metal_device_network":           resourceMetalDeviceNetwork(),
+			"equinix_metal_device_network_type":      resourceMetalDeviceNetworkType(),
+			"equinix_metal_ssh_key":                  resourceMetalSSHKey(),
+			"equinix_metal_organization_member":      resourceMetalOrganizationMember(),
+			"equinix_metal_port":                     resourceMetalPort(),
+			"equinix_metal_project_ssh_key":          resourceMetalProjectSSHKey(),
+			"equinix_metal_project":                  resourceMetalProject(),
+			"equinix_metal_organization":             resourceMetalOrganization(),
+			"equinix_metal_reserved_ip_block":        resourceMetalReservedIPBlock(),
+			"equinix_metal_ip_attachment":            resourceMetalIPAttachment(),
+			"equinix_metal_spot_market_request":      resourceMetalSpotMarketRequest(),
+			"equinix_metal_vlan":                     resourceMetalVlan(),
+			"equinix_metal_virtual_circuit":          resourceMetalVirtualCircuit(),
+			"equinix_metal_vrf":                      resourceMetalVRF(),
+			"equinix_metal_vrf_bgp_dynamic_neighbor": resourceMetalVRFBGPDynamicNeighbor(),
+			"equinix_metal_vrf_route":                resourceMetalVRFRoute(),
+			"equinix_metal_bgp_session":              resourceMetalBGPSession(),
+			"equinix_metal_port_vlan_attachment":     resourceMetalPortVlanAttachment(),
+			"equinix_metal_gateway":                  resourceMetalGateway(),
 		},
 		ProviderMetaSchema: map[string]*schema.Schema{
 			"module_name": {
@@ -12,6 +12,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,7 +37,7 @@ func resourceMetalDevice() *schema.Resource {
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
-		CreateContext:      diagnosticsWrapper(resourceMetalDeviceCreate),
+		CreateContext:      resourceMetalDeviceCreateWithCapacityWarning,
 		ReadWithoutTimeout: diagnosticsWrapper(resourceMetalDeviceRead),
 		UpdateContext:      diagnosticsWrapper(resourceMetalDeviceUpdate),
 		DeleteContext:      diagnosticsWrapper(resourceMetalDeviceDelete),
@@ -254,18 +255,20 @@ func resourceMetalDevice() *schema.Resource {
 				Computed:    true,
 			},
 			"user_data": {
-				Type:        schema.TypeString,
-				Description: "A string of the desired User Data for the device.  By default, changing this attribute will cause the provider to destroy and recreate your device.  If `reinstall` is specified or `behavior.allow_changes` includes `\"user_data\"`, the device will be updated in-place instead of recreated.",
-				Optional:    true,
-				Sensitive:   true,
-				ForceNew:    false, // Computed; see CustomizeDiff below
+				Type:         schema.TypeString,
+				Description:  "A string of the desired User Data for the device.  By default, changing this attribute will cause the provider to destroy and recreate your device.  If `reinstall` is specified or `behavior.allow_changes` includes `\"user_data\"`, the device will be updated in-place instead of recreated.",
+				Optional:     true,
+				Sensitive:    true,
+				ForceNew:     false, // Computed; see CustomizeDiff below
+				ValidateFunc: validateUserData,
 			},
 			"custom_data": {
-				Type:        schema.TypeString,
-				Description: "A string of the desired Custom Data for the device.  By default, changing this attribute will cause the provider to destroy and recreate your device.  If `reinstall` is specified or `behavior.allow_changes` includes `\"custom_data\"`, the device will be updated in-place instead of recreated.",
-				Optional:    true,
-				Sensitive:   true,
-				ForceNew:    false, // Computed; see CustomizeDiff below
+				Type:         schema.TypeString,
+				Description:  "A string of the desired Custom Data for the device.  By default, changing this attribute will cause the provider to destroy and recreate your device.  If `reinstall` is specified or `behavior.allow_changes` includes `\"custom_data\"`, the device will be updated in-place instead of recreated.",
+				Optional:     true,
+				Sensitive:    true,
+				ForceNew:     false, // Computed; see CustomizeDiff below
+				ValidateFunc: validateCustomData,
 			},
 			"ipxe_script_url": {
 				Type:        schema.TypeString,
@@ -308,8 +311,10 @@ func resourceMetalDevice() *schema.Resource {
 					s, _ := structure.NormalizeJsonString(v)
 					return s
 				},
-				ValidateFunc: validation.StringIsJSON,
+				ValidateFunc:  validation.StringIsJSON,
+				ConflictsWith: []string{"storage_layout"},
 			},
+			"storage_layout": storageLayoutSchema(),
 			"project_ssh_key_ids": {
 				Type:        schema.TypeList,
 				Description: "Array of IDs of the project SSH keys which should be added to the device. If you specify this array, only the listed project SSH keys (and any SSH keys for the users specified in user_ssh_key_ids) will be added. If no SSH keys are specified (both user_ssh_keys_ids and project_ssh_key_ids are empty lists or omitted), all parent project keys, parent project members keys and organization members keys will be included.  Project SSH keys can be created with the [equinix_metal_project_ssh_key](equinix_metal_project_ssh_key.md) resource",
@@ -409,6 +414,12 @@ func resourceMetalDevice() *schema.Resource {
 					},
 				},
 			},
+			"capacity_check": {
+				Type:         schema.TypeString,
+				Description:  "Check at plan time that the metro has capacity for all the devices of the same plan that are being created. With \"error\" insufficient capacity fails the plan, with \"warn\" it is reported as a warning when the device is created",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"warn", "error"}, false),
+			},
 			"sos_hostname": {
 				Type:        schema.TypeString,
 				Description: "The hostname to use for [Serial over SSH](https://deploy.equinix.com/developers/docs/metal/resilience-recovery/serial-over-ssh/) access to the device",
@@ -419,6 +430,8 @@ func resourceMetalDevice() *schema.Resource {
 			customdiff.ForceNewIf("custom_data", reinstallDisabledAndNoChangesAllowed("custom_data")),
 			customdiff.ForceNewIf("operating_system", reinstallDisabled),
 			customdiff.ForceNewIf("user_data", reinstallDisabledAndNoChangesAllowed("user_data")),
+			checkPlannedDeviceCapacity,
+			checkPlannedStorageLayout,
 		),
 	}
 }
@@ -472,6 +485,21 @@ func reinstallDisabledAndNoChangesAllowed(attribute string) customdiff.ResourceC
 	}
 }
 
+// resourceMetalDeviceCreateWithCapacityWarning creates the device and reports
+// the insufficient capacity found by a capacity_check of "warn" at plan time.
+func resourceMetalDeviceCreateWithCapacityWarning(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	deviceKey := plannedDeviceKey(d.Get("project_id").(string), d.Get("hostname").(string))
+	if warning := meta.(*Config).plannedDevices.warning(deviceKey); warning != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  warning,
+			Detail:   fmt.Sprintf("The capacity check of device %s found insufficient capacity, provisioning may fail", d.Get("hostname").(string)),
+		})
+	}
+	return append(diags, diagnosticsWrapper(resourceMetalDeviceCreate)(ctx, d, meta)...)
+}
+
 func resourceMetalDeviceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
@@ -588,6 +616,13 @@ func resourceMetalDeviceCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 		createRequest.Storage = &cpr
 	}
+	if layout, ok := d.GetOk("storage_layout"); ok {
+		cpr, err := expandStorageLayout(layout.([]interface{}))
+		if err != nil {
+			return err
+		}
+		createRequest.Storage = cpr
+	}
 
 	start := time.Now()
 	newDevice, _, err := client.Devices.Create(createRequest)
@@ -647,7 +682,11 @@ func resourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.Set("root_password", device.GetRootPassword())
 	d.Set("project_id", device.Project.GetId())
 	d.Set("sos_hostname", device.GetSos())
-	if device.Storage != nil {
+	if _, ok := d.GetOk("storage_layout"); ok {
+		if err := d.Set("storage_layout", flattenStorageLayout(device.Storage)); err != nil {
+			return fmt.Errorf("[ERR] Error setting storage_layout for device (%s): %s", d.Id(), err)
+		}
+	} else if device.Storage != nil {
 		rawStorageBytes, err := json.Marshal(device.Storage)
 		if err != nil {
 			return fmt.Errorf("[ERR] Error getting storage JSON string for device (%s): %s", d.Id(), err)
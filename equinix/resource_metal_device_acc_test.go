@@ -314,6 +314,24 @@ func TestAccMetalDevice_metro(t *testing.T) {
 	})
 }
 
+func TestAccMetalDevice_capacityCheck(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalDeviceCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMetalDeviceConfig_capacityCheck(rs, 1000),
+				PlanOnly:    true,
+				ExpectError: matchErrNoCapacity,
+			},
+		},
+	})
+}
+
 func TestAccMetalDevice_update(t *testing.T) {
 	var d1, d2, d3, d4, d5 packngo.Device
 	rs := acctest.RandString(10)
@@ -818,6 +836,25 @@ resource "equinix_metal_device" "test" {
 }`, confAccMetalDevice_base(preferable_plans, preferable_metros, preferable_os), projSuffix)
 }
 
+func testAccMetalDeviceConfig_capacityCheck(projSuffix string, count int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_project" "test" {
+    name = "tfacc-device-%s"
+}
+
+resource "equinix_metal_device" "test" {
+  count            = %d
+  hostname         = "tfacc-test-device-${count.index}"
+  plan             = local.plan
+  metro            = local.metro
+  operating_system = local.os
+  project_id       = "${equinix_metal_project.test.id}"
+  capacity_check   = "error"
+}`, confAccMetalDevice_base(preferable_plans, preferable_metros, preferable_os), projSuffix, count)
+}
+
 func testAccMetalDeviceConfig_basic(projSuffix string) string {
 	return fmt.Sprintf(`
 %s
@@ -0,0 +1,699 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+const (
+	deviceBatchInstanceActive  = "active"
+	deviceBatchInstanceFailed  = "failed"
+	deviceBatchInstanceDeleted = "deleted"
+	deviceBatchInstanceQueued  = "queued"
+)
+
+// deviceBatchInstance is a device of the batch resource, identified by its
+// index in the batch.
+type deviceBatchInstance struct {
+	Index    int
+	Hostname string
+	DeviceID string
+	State    string
+	Error    string
+
+	// BatchID is the API batch which creates the device and
+	// PreviousDeviceID the failed device it replaces, they are only set
+	// until the created device is found
+	BatchID          string
+	PreviousDeviceID string
+}
+
+func resourceMetalDeviceBatch() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		ReadWithoutTimeout: diagnosticsWrapper(resourceMetalDeviceBatchRead),
+		// Create and Update are the same func
+		CreateContext: resourceMetalDeviceBatchUpdate,
+		UpdateContext: resourceMetalDeviceBatchUpdate,
+		DeleteContext: diagnosticsWrapper(resourceMetalDeviceBatchDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMetalDeviceBatchImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the project in which to create the devices",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Description:  "Number of devices in the batch. Changing it creates or deletes devices in place",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"hostname_pattern": {
+				Type:         schema.TypeString,
+				Description:  "Format of the device hostnames with a single integer verb replaced by the index of the device, e.g. render-%03d",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDeviceBatchHostnamePattern,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Description:  "How many times a device which fails to provision is deleted and created again in one apply. Devices which still fail are reported and retried in the next apply",
+				Optional:     true,
+				Default:      2,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"template": {
+				Type:        schema.TypeList,
+				Description: "Configuration of the devices of the batch. Changing it recreates all the devices",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plan": {
+							Type:        schema.TypeString,
+							Description: "The device plan slug",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"metro": {
+							Type:        schema.TypeString,
+							Description: "Metro area for the new devices",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"operating_system": {
+							Type:        schema.TypeString,
+							Description: "The operating system slug",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"billing_cycle": {
+							Type:        schema.TypeString,
+							Description: "monthly or hourly",
+							Optional:    true,
+							Default:     "hourly",
+							ForceNew:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "Description string for the devices",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"user_data": {
+							Type:         schema.TypeString,
+							Description:  "A string of the desired User Data for the devices",
+							Optional:     true,
+							Sensitive:    true,
+							ForceNew:     true,
+							ValidateFunc: validateUserData,
+						},
+						"custom_data": {
+							Type:         schema.TypeString,
+							Description:  "A string of the desired Custom Data for the devices",
+							Optional:     true,
+							Sensitive:    true,
+							ForceNew:     true,
+							ValidateFunc: validateCustomData,
+						},
+						"ipxe_script_url": {
+							Type:        schema.TypeString,
+							Description: "URL pointing to a hosted iPXE script",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"always_pxe": {
+							Type:        schema.TypeBool,
+							Description: "If true, a device with OS custom_ipxe will continue to boot via iPXE on reboots",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Description: "Tags attached to the devices",
+							Optional:    true,
+							ForceNew:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"project_ssh_key_ids": {
+							Type:        schema.TypeList,
+							Description: "Array of IDs of the project SSH keys which should be added to the devices",
+							Optional:    true,
+							ForceNew:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"instance": {
+				Type:        schema.TypeList,
+				Description: "Devices of the batch ordered by index",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:        schema.TypeInt,
+							Description: "Index of the device in the batch",
+							Computed:    true,
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Description: "Hostname of the device",
+							Computed:    true,
+						},
+						"device_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the device, empty if the device could not be created",
+							Computed:    true,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Description: "State of the device, failed if it could not be provisioned and deleted if it was removed outside of Terraform",
+							Computed:    true,
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Description: "Why the device could not be provisioned",
+							Computed:    true,
+						},
+						"batch_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the API batch creating the device, empty once the device exists",
+							Computed:    true,
+						},
+						"previous_device_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the failed device replaced by the device being created",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: resourceMetalDeviceBatchDiff,
+	}
+}
+
+func validateDeviceBatchHostnamePattern(v interface{}, k string) (ws []string, errs []error) {
+	pattern := v.(string)
+	hostname := fmt.Sprintf(pattern, 1)
+	if strings.Contains(hostname, "%!") || hostname == fmt.Sprintf(pattern, 2) {
+		errs = append(errs, fmt.Errorf("%q must contain a single integer verb like %%d or %%03d, got %q", k, pattern))
+	}
+	return ws, errs
+}
+
+// resourceMetalDeviceBatchDiff plans an update of the instances when the
+// quantity changes or when some devices are not active, so that failed and
+// deleted devices are created again and devices still being created are
+// waited for.
+func resourceMetalDeviceBatchDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("quantity") {
+		return d.SetNewComputed("instance")
+	}
+	for _, inst := range expandDeviceBatchInstances(d.Get("instance").([]interface{})) {
+		if inst.State == deviceBatchInstanceFailed || inst.State == deviceBatchInstanceDeleted || inst.BatchID != "" {
+			return d.SetNewComputed("instance")
+		}
+	}
+	return nil
+}
+
+func expandDeviceBatchInstances(raw []interface{}) []*deviceBatchInstance {
+	instances := make([]*deviceBatchInstance, 0, len(raw))
+	for _, r := range raw {
+		m := r.(map[string]interface{})
+		inst := &deviceBatchInstance{
+			Index:    m["index"].(int),
+			Hostname: m["hostname"].(string),
+			DeviceID: m["device_id"].(string),
+			State:    m["state"].(string),
+			Error:    m["error"].(string),
+		}
+		// instances saved before the batch attributes were added
+		if v, ok := m["batch_id"].(string); ok {
+			inst.BatchID = v
+		}
+		if v, ok := m["previous_device_id"].(string); ok {
+			inst.PreviousDeviceID = v
+		}
+		instances = append(instances, inst)
+	}
+	return instances
+}
+
+func flattenDeviceBatchInstances(instances []*deviceBatchInstance) []map[string]interface{} {
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Index < instances[j].Index })
+	flat := make([]map[string]interface{}, 0, len(instances))
+	for _, inst := range instances {
+		flat = append(flat, map[string]interface{}{
+			"index":              inst.Index,
+			"hostname":           inst.Hostname,
+			"device_id":          inst.DeviceID,
+			"state":              inst.State,
+			"error":              inst.Error,
+			"batch_id":           inst.BatchID,
+			"previous_device_id": inst.PreviousDeviceID,
+		})
+	}
+	return flat
+}
+
+// planDeviceBatch splits the instances to the ones to delete, because they
+// are above the quantity, and the ones to create. The instances to create
+// are the failed and deleted ones and the missing indexes below the
+// quantity. Instances whose batch is still creating the device are not
+// created again.
+func planDeviceBatch(instances []*deviceBatchInstance, quantity int, hostnamePattern string) (remove, create []*deviceBatchInstance) {
+	byIndex := map[int]*deviceBatchInstance{}
+	for _, inst := range instances {
+		if inst.Index >= quantity {
+			remove = append(remove, inst)
+			continue
+		}
+		byIndex[inst.Index] = inst
+	}
+	for i := 0; i < quantity; i++ {
+		inst, ok := byIndex[i]
+		switch {
+		case !ok:
+			create = append(create, &deviceBatchInstance{Index: i, Hostname: fmt.Sprintf(hostnamePattern, i)})
+		case inst.DeviceID == "" && inst.BatchID == "",
+			inst.State == deviceBatchInstanceFailed,
+			inst.State == deviceBatchInstanceDeleted:
+			create = append(create, inst)
+		}
+	}
+	return remove, create
+}
+
+func containsDeviceBatchInstance(instances []*deviceBatchInstance, inst *deviceBatchInstance) bool {
+	for _, i := range instances {
+		if i == inst {
+			return true
+		}
+	}
+	return false
+}
+
+func deviceBatchTemplate(d *schema.ResourceData) packngo.DeviceCreateRequest {
+	t := d.Get("template").([]interface{})[0].(map[string]interface{})
+	req := packngo.DeviceCreateRequest{
+		Plan:          t["plan"].(string),
+		Metro:         t["metro"].(string),
+		OS:            t["operating_system"].(string),
+		BillingCycle:  t["billing_cycle"].(string),
+		ProjectID:     d.Get("project_id").(string),
+		UserData:      t["user_data"].(string),
+		CustomData:    t["custom_data"].(string),
+		IPXEScriptURL: t["ipxe_script_url"].(string),
+		AlwaysPXE:     t["always_pxe"].(bool),
+		Tags:          convertStringArr(t["tags"].([]interface{})),
+	}
+	if desc := t["description"].(string); desc != "" {
+		req.Description = desc
+	}
+	if keys := convertStringArr(t["project_ssh_key_ids"].([]interface{})); len(keys) > 0 {
+		req.ProjectSSHKeys = keys
+	}
+	return req
+}
+
+// createDeviceBatch requests all the instances in one batch call. Every
+// instance is a batch of one device so that it gets its own hostname and its
+// own error messages. The batches returned by the API are recorded even if
+// some are missing, so that their devices are tracked.
+func createDeviceBatch(client *packngo.Client, template packngo.DeviceCreateRequest, instances []*deviceBatchInstance) error {
+	req := &packngo.BatchCreateRequest{}
+	for _, inst := range instances {
+		dcr := template
+		dcr.Hostname = inst.Hostname
+		req.Batches = append(req.Batches, packngo.BatchCreateDevice{
+			DeviceCreateRequest: dcr,
+			Quantity:            1,
+		})
+	}
+	batches, _, err := client.Batches.Create(template.ProjectID, req)
+	if err != nil {
+		return friendlyError(err)
+	}
+	for i, inst := range instances {
+		if i >= len(batches) {
+			break
+		}
+		inst.BatchID = batches[i].ID
+		inst.PreviousDeviceID = inst.DeviceID
+		inst.DeviceID = ""
+		inst.State = deviceBatchInstanceQueued
+		inst.Error = ""
+	}
+	if len(batches) != len(instances) {
+		return fmt.Errorf("expected %d batches to be created, got %d", len(instances), len(batches))
+	}
+	return nil
+}
+
+// refreshDeviceBatchInstances updates the instances with a single listing of
+// the project devices. Instances still being created are matched to their
+// devices by hostname, and their batch is checked only while the device
+// doesn't exist yet.
+func refreshDeviceBatchInstances(client *packngo.Client, projectID string, instances []*deviceBatchInstance) error {
+	devices, _, err := client.Devices.List(projectID, nil)
+	if err != nil {
+		return friendlyError(err)
+	}
+	byID := map[string]*packngo.Device{}
+	for i := range devices {
+		byID[devices[i].ID] = &devices[i]
+	}
+	known := map[string]bool{}
+	for _, inst := range instances {
+		if inst.DeviceID != "" {
+			known[inst.DeviceID] = true
+		}
+	}
+
+	for _, inst := range instances {
+		if inst.DeviceID == "" && inst.BatchID != "" {
+			for _, dev := range devices {
+				if dev.Hostname == inst.Hostname && dev.ID != inst.PreviousDeviceID && !known[dev.ID] {
+					inst.DeviceID = dev.ID
+					inst.BatchID = ""
+					inst.PreviousDeviceID = ""
+					known[dev.ID] = true
+					break
+				}
+			}
+		}
+		switch {
+		case inst.DeviceID != "":
+			dev, ok := byID[inst.DeviceID]
+			if !ok {
+				inst.State = deviceBatchInstanceDeleted
+				continue
+			}
+			inst.State = dev.State
+			if dev.State == deviceBatchInstanceFailed && inst.Error == "" {
+				inst.Error = "device failed to provision"
+			}
+		case inst.BatchID != "":
+			batch, _, err := client.Batches.Get(inst.BatchID, nil)
+			if err != nil {
+				return friendlyError(err)
+			}
+			if batch.State == deviceBatchInstanceFailed {
+				inst.State = deviceBatchInstanceFailed
+				inst.Error = strings.Join(batch.ErrorMessages, "; ")
+				inst.BatchID = ""
+				inst.PreviousDeviceID = ""
+			}
+		}
+	}
+	return nil
+}
+
+// waitForDeviceBatch waits until all the instances are active or failed,
+// with a single waiter for the whole batch.
+func waitForDeviceBatch(ctx context.Context, client *packngo.Client, projectID string, instances []*deviceBatchInstance, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"provisioning"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			if err := refreshDeviceBatchInstances(client, projectID, instances); err != nil {
+				return nil, "", err
+			}
+			for _, inst := range instances {
+				if inst.State != deviceBatchInstanceActive && inst.State != deviceBatchInstanceFailed && inst.State != deviceBatchInstanceDeleted {
+					return instances, "provisioning", nil
+				}
+			}
+			return instances, "done", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func deleteDeviceBatchInstance(client *packngo.Client, inst *deviceBatchInstance) error {
+	if inst.DeviceID == "" {
+		return nil
+	}
+	resp, err := client.Devices.Delete(inst.DeviceID, false)
+	if ignoreResponseErrors(httpForbidden, httpNotFound)(resp, err) != nil {
+		return fmt.Errorf("device %s (%s): %s", inst.Hostname, inst.DeviceID, friendlyError(err))
+	}
+	return nil
+}
+
+func resourceMetalDeviceBatchUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+	start := time.Now()
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	timeout -= 30 * time.Second
+
+	projectID := d.Get("project_id").(string)
+	instances := expandDeviceBatchInstances(d.Get("instance").([]interface{}))
+	if len(instances) > 0 {
+		if err := refreshDeviceBatchInstances(client, projectID, instances); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// devices of batches requested in an earlier apply are waited for
+	// instead of being requested again
+	if pending := pendingDeviceBatchInstances(instances); len(pending) > 0 {
+		if err := waitForDeviceBatch(ctx, client, projectID, pending, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	quantity := d.Get("quantity").(int)
+	remove, create := planDeviceBatch(instances, quantity, d.Get("hostname_pattern").(string))
+	kept := []*deviceBatchInstance{}
+	for _, inst := range instances {
+		if inst.Index < quantity {
+			kept = append(kept, inst)
+		}
+	}
+	for _, inst := range create {
+		if !containsDeviceBatchInstance(instances, inst) {
+			kept = append(kept, inst)
+		}
+	}
+	// the instances are saved after every step, so that the devices are
+	// tracked even if a later step fails
+	save := func(err error) diag.Diagnostics {
+		if setErr := d.Set("instance", flattenDeviceBatchInstances(kept)); setErr != nil {
+			return diag.FromErr(setErr)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	for _, inst := range remove {
+		if err := deleteDeviceBatchInstance(client, inst); err != nil {
+			kept = append(kept, inst)
+			return save(err)
+		}
+	}
+
+	template := deviceBatchTemplate(d)
+	for attempt := 0; len(create) > 0 && attempt <= d.Get("max_retries").(int); attempt++ {
+		if attempt > 0 {
+			log.Printf("[WARN] Retrying %d failed devices of batch %s", len(create), d.Id())
+		}
+		for _, inst := range create {
+			if err := deleteDeviceBatchInstance(client, inst); err != nil {
+				return save(err)
+			}
+		}
+		err := createDeviceBatch(client, template, create)
+		if d.Id() == "" && create[0].BatchID != "" {
+			d.SetId(create[0].BatchID)
+		}
+		if err != nil {
+			return save(err)
+		}
+		if err := waitForDeviceBatch(ctx, client, projectID, create, timeout-time.Since(start)); err != nil {
+			return save(err)
+		}
+
+		failed := []*deviceBatchInstance{}
+		for _, inst := range create {
+			if inst.State != deviceBatchInstanceActive {
+				failed = append(failed, inst)
+			}
+		}
+		create = failed
+	}
+
+	diags := save(nil)
+	for _, inst := range create {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Device %s of the batch failed to provision", inst.Hostname),
+			Detail:   fmt.Sprintf("%s. It will be created again in the next apply.", inst.Error),
+		})
+	}
+	return diags
+}
+
+func resourceMetalDeviceBatchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	instances := expandDeviceBatchInstances(d.Get("instance").([]interface{}))
+	if err := refreshDeviceBatchInstances(client, d.Get("project_id").(string), instances); err != nil {
+		if isNotFound(err) || isForbidden(err) {
+			log.Printf("[WARN] Project (%s) of device batch %s not accessible, removing from state", d.Get("project_id").(string), d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	return d.Set("instance", flattenDeviceBatchInstances(instances))
+}
+
+func resourceMetalDeviceBatchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+	projectID := d.Get("project_id").(string)
+
+	instances := expandDeviceBatchInstances(d.Get("instance").([]interface{}))
+	if err := refreshDeviceBatchInstances(client, projectID, instances); err != nil {
+		if isNotFound(err) || isForbidden(err) {
+			return nil
+		}
+		return err
+	}
+	// devices still being created are waited for, so that they are deleted too
+	if pending := pendingDeviceBatchInstances(instances); len(pending) > 0 {
+		if err := waitForDeviceBatch(ctx, client, projectID, pending, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
+	var errs []string
+	for _, inst := range instances {
+		if err := deleteDeviceBatchInstance(client, inst); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error deleting devices of batch %s: %s", d.Id(), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// pendingDeviceBatchInstances returns the instances whose devices are still
+// being created by their batch.
+func pendingDeviceBatchInstances(instances []*deviceBatchInstance) []*deviceBatchInstance {
+	pending := []*deviceBatchInstance{}
+	for _, inst := range instances {
+		if inst.BatchID != "" {
+			pending = append(pending, inst)
+		}
+	}
+	return pending
+}
+
+// resourceMetalDeviceBatchImport imports the devices of a project named by
+// the hostname pattern, with an ID of the form {project_id}:{hostname_pattern}.
+// The devices are looked up from index 0 until an index has no device, and
+// the template is taken from the first device. user_data, custom_data and
+// project_ssh_key_ids can't be read back from the devices.
+func resourceMetalDeviceBatchImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected {project_id}:{hostname_pattern}", d.Id())
+	}
+	projectID, pattern := parts[0], parts[1]
+	if _, errs := validateDeviceBatchHostnamePattern(pattern, "hostname_pattern"); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	devices, _, err := meta.(*Config).metal.Devices.List(projectID, nil)
+	if err != nil {
+		return nil, friendlyError(err)
+	}
+	byHostname := map[string]*packngo.Device{}
+	for i := range devices {
+		byHostname[devices[i].Hostname] = &devices[i]
+	}
+	instances := []*deviceBatchInstance{}
+	for i := 0; ; i++ {
+		dev, ok := byHostname[fmt.Sprintf(pattern, i)]
+		if !ok {
+			break
+		}
+		instances = append(instances, &deviceBatchInstance{Index: i, Hostname: dev.Hostname, DeviceID: dev.ID, State: dev.State})
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no device of project %s matches hostname pattern %q", projectID, pattern)
+	}
+
+	first := byHostname[instances[0].Hostname]
+	template := map[string]interface{}{
+		"billing_cycle":   first.BillingCycle,
+		"ipxe_script_url": first.IPXEScriptURL,
+		"always_pxe":      first.AlwaysPXE,
+		"tags":            first.Tags,
+	}
+	if first.Plan != nil {
+		template["plan"] = first.Plan.Slug
+	}
+	if first.Metro != nil {
+		template["metro"] = first.Metro.Code
+	}
+	if first.OS != nil {
+		template["operating_system"] = first.OS.Slug
+	}
+	if first.Description != nil {
+		template["description"] = *first.Description
+	}
+
+	err = setMap(d, map[string]interface{}{
+		"project_id":       projectID,
+		"hostname_pattern": pattern,
+		"quantity":         len(instances),
+		"max_retries":      2,
+		"template":         []interface{}{template},
+		"instance":         flattenDeviceBatchInstances(instances),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
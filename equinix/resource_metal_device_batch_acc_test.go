@@ -0,0 +1,101 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func confAccMetalDeviceBatch(name string, quantity int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_project" "test" {
+  name = "tfacc-device-batch-test-%s"
+}
+
+resource "equinix_metal_device_batch" "test" {
+  project_id       = equinix_metal_project.test.id
+  quantity         = %d
+  hostname_pattern = "tfacc-batch-%%02d"
+
+  template {
+    plan             = local.plan
+    metro            = local.metro
+    operating_system = local.os
+    tags             = ["tfacc"]
+  }
+}
+`, confAccMetalDevice_base(preferable_plans, preferable_metros, preferable_os), name, quantity)
+}
+
+func TestAccMetalDeviceBatch_scale(t *testing.T) {
+	rs := acctest.RandString(10)
+	r := "equinix_metal_device_batch.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalDeviceBatchDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: confAccMetalDeviceBatch(rs, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(r, "instance.#", "2"),
+					resource.TestCheckResourceAttr(r, "instance.0.hostname", "tfacc-batch-00"),
+					resource.TestCheckResourceAttr(r, "instance.1.hostname", "tfacc-batch-01"),
+					resource.TestCheckResourceAttr(r, "instance.0.state", "active"),
+					resource.TestCheckResourceAttr(r, "instance.1.state", "active"),
+				),
+			},
+			{
+				Config: confAccMetalDeviceBatch(rs, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(r, "instance.#", "3"),
+					resource.TestCheckResourceAttr(r, "instance.2.hostname", "tfacc-batch-02"),
+					resource.TestCheckResourceAttr(r, "instance.2.state", "active"),
+				),
+			},
+			{
+				Config: confAccMetalDeviceBatch(rs, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(r, "instance.#", "1"),
+					resource.TestCheckResourceAttr(r, "instance.0.hostname", "tfacc-batch-00"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetalDeviceBatchDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).metal
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "equinix_metal_device_batch" {
+			continue
+		}
+		for _, inst := range expandDeviceBatchInstancesFromState(rs.Primary.Attributes) {
+			if d, _, err := client.Devices.Get(inst, nil); err == nil && d.State != "deprovisioning" {
+				return fmt.Errorf("Metal device %s of batch %s still exists", inst, rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func expandDeviceBatchInstancesFromState(attrs map[string]string) []string {
+	ids := []string{}
+	for i := 0; ; i++ {
+		id, ok := attrs[fmt.Sprintf("instance.%d.device_id", i)]
+		if !ok {
+			return ids
+		}
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateDeviceBatchHostnamePattern(t *testing.T) {
+	// given
+	valid := []string{"render-%d", "render-%03d", "%d.example.com"}
+	invalid := []string{"render", "render-%s", "render-%d-%d"}
+	for _, pattern := range valid {
+		// when
+		_, errs := validateDeviceBatchHostnamePattern(pattern, "hostname_pattern")
+		// then
+		assert.Empty(t, errs, pattern)
+	}
+	for _, pattern := range invalid {
+		// when
+		_, errs := validateDeviceBatchHostnamePattern(pattern, "hostname_pattern")
+		// then
+		assert.Len(t, errs, 1, pattern)
+	}
+}
+
+func Test_planDeviceBatch(t *testing.T) {
+	// given
+	instances := []*deviceBatchInstance{
+		{Index: 0, Hostname: "r-00", DeviceID: "a", State: deviceBatchInstanceActive},
+		{Index: 1, Hostname: "r-01", DeviceID: "b", State: deviceBatchInstanceFailed},
+		{Index: 2, Hostname: "r-02", DeviceID: "c", State: deviceBatchInstanceDeleted},
+		{Index: 4, Hostname: "r-04", DeviceID: "e", State: deviceBatchInstanceActive},
+		{Index: 5, Hostname: "r-05", DeviceID: "f", State: deviceBatchInstanceActive},
+	}
+	// when
+	remove, create := planDeviceBatch(instances, 5, "r-%02d")
+	// then
+	assert.Len(t, remove, 1)
+	assert.Equal(t, "f", remove[0].DeviceID)
+	assert.Len(t, create, 3)
+	assert.Equal(t, "b", create[0].DeviceID)
+	assert.Equal(t, "c", create[1].DeviceID)
+	assert.Equal(t, 3, create[2].Index)
+	assert.Equal(t, "r-03", create[2].Hostname)
+	assert.Empty(t, create[2].DeviceID)
+}
+
+func Test_flattenDeviceBatchInstances(t *testing.T) {
+	// given
+	raw := []interface{}{
+		map[string]interface{}{"index": 1, "hostname": "r-01", "device_id": "b", "state": "failed", "error": "no capacity"},
+		map[string]interface{}{"index": 0, "hostname": "r-00", "device_id": "", "state": "queued", "error": "", "batch_id": "batch-0", "previous_device_id": "a"},
+	}
+	// when
+	flat := flattenDeviceBatchInstances(expandDeviceBatchInstances(raw))
+	// then
+	assert.Len(t, flat, 2)
+	assert.Equal(t, "r-00", flat[0]["hostname"])
+	assert.Equal(t, "no capacity", flat[1]["error"])
+	assert.Equal(t, "batch-0", flat[0]["batch_id"])
+	assert.Equal(t, "a", flat[0]["previous_device_id"])
+}
+
+func Test_planDeviceBatch_pending(t *testing.T) {
+	// given
+	instances := []*deviceBatchInstance{
+		{Index: 0, Hostname: "r-00", State: deviceBatchInstanceQueued, BatchID: "batch-0", PreviousDeviceID: "a"},
+		{Index: 1, Hostname: "r-01", State: deviceBatchInstanceQueued},
+	}
+	// when
+	_, create := planDeviceBatch(instances, 2, "r-%02d")
+	pending := pendingDeviceBatchInstances(instances)
+	// then
+	assert.Len(t, create, 1, "Instance with a batch creating its device is not created again")
+	assert.Equal(t, 1, create[0].Index)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "batch-0", pending[0].BatchID)
+}
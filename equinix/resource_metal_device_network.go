@@ -0,0 +1,315 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func resourceMetalDeviceNetwork() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		ReadWithoutTimeout: diagnosticsWrapper(resourceMetalDeviceNetworkRead),
+		// Create and Update are the same func
+		CreateContext: diagnosticsWrapper(resourceMetalDeviceNetworkUpdate),
+		UpdateContext: diagnosticsWrapper(resourceMetalDeviceNetworkUpdate),
+		DeleteContext: diagnosticsWrapper(resourceMetalDeviceNetworkDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("device_id", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the device whose network is configured",
+			},
+			"port": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Network configuration of the device ports. Ports which are not listed are left as they are",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the port, e.g. bond0, eth1",
+						},
+						"bonded": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Flag indicating whether the port should be bonded",
+						},
+						"layer2": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Flag indicating whether the port is in layer2 (or layer3) mode. The `layer2` flag can be set only for bond ports",
+						},
+						"native_vlan_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "UUID of native VLAN of the port",
+						},
+						"vlan_ids": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "UUIDs of VLANs to attach to the port",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"port_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the port",
+						},
+						"network_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of layer2-bonded, layer2-individual, layer3, hybrid and hybrid-bonded. This attribute is only set on bond ports",
+						},
+					},
+				},
+			},
+			"reset_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Behavioral setting to reset the listed ports to default settings (layer3 bonded mode without any vlan attached) before delete/destroy",
+			},
+			"network_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resulting network type of the device, one of " + NetworkTypeListHB,
+			},
+		},
+	}
+}
+
+// getDeviceNetworkPorts returns a ClientPortResource for every port listed in
+// the port blocks. The Resource of each is an ephemeral equinix_metal_port
+// state built from the block, so that the equinix_metal_port helpers can be
+// reused for the device ports.
+func getDeviceNetworkPorts(d *schema.ResourceData, meta interface{}, portBlocks []interface{}) ([]*ClientPortResource, error) {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	deviceID := d.Get("device_id").(string)
+	device, _, err := client.Devices.Get(deviceID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cprs := make([]*ClientPortResource, 0, len(portBlocks))
+	for _, raw := range portBlocks {
+		block := raw.(map[string]interface{})
+		devPort, err := device.GetPortByName(block["name"].(string))
+		if err != nil {
+			return nil, err
+		}
+		cpr := &ClientPortResource{
+			Client: client,
+			Port:   devPort,
+		}
+		if err := refreshPort(cpr); err != nil {
+			return nil, err
+		}
+		if cpr.Resource, err = portResourceDataFromBlock(block, cpr.Port); err != nil {
+			return nil, err
+		}
+		cprs = append(cprs, cpr)
+	}
+	return cprs, nil
+}
+
+func portResourceDataFromBlock(block map[string]interface{}, port *packngo.Port) (*schema.ResourceData, error) {
+	pd := resourceMetalPort().Data(nil)
+	m := map[string]interface{}{
+		"port_id":        port.ID,
+		"bonded":         block["bonded"],
+		"native_vlan_id": block["native_vlan_id"],
+		"vlan_ids":       block["vlan_ids"].(*schema.Set).List(),
+	}
+	// layer2 of a bond port defaults to layer3, layer2 set on a physical
+	// port is left to portSanityChecks to reject
+	l2 := block["layer2"].(bool)
+	if port.Type == "NetworkBondPort" || l2 {
+		m["layer2"] = l2
+	}
+	if err := setMap(pd, m); err != nil {
+		return nil, err
+	}
+	return pd, nil
+}
+
+func refreshPort(cpr *ClientPortResource) error {
+	getOpts := &packngo.GetOptions{Includes: []string{
+		"native_virtual_network",
+		"virtual_networks",
+	}}
+	port, _, err := cpr.Client.Ports.Get(cpr.Port.ID, getOpts)
+	if err != nil {
+		return err
+	}
+	*(cpr.Port) = *port
+	return nil
+}
+
+// convergeDevicePorts applies every step to all the ports before moving on to
+// the next step. Bonding and network type changes of one port change the
+// other ports of the device, so the ports are re-read between the steps.
+func convergeDevicePorts(cprs []*ClientPortResource, steps [](func(*ClientPortResource) error)) error {
+	for i, f := range steps {
+		for _, cpr := range cprs {
+			if i > 0 {
+				if err := refreshPort(cpr); err != nil {
+					return err
+				}
+			}
+			if err := f(cpr); err != nil {
+				return fmt.Errorf("port %s: %s", cpr.Port.Name, friendlyError(err))
+			}
+		}
+	}
+	return nil
+}
+
+func resourceMetalDeviceNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	start := time.Now()
+	cprs, err := getDeviceNetworkPorts(d, meta, d.Get("port").([]interface{}))
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	if err := convergeDevicePorts(cprs, [](func(*ClientPortResource) error){
+		portSanityChecks,
+		batchVlans(ctx, start, true),
+		makeDisbond,
+		convertToL2,
+		makeBond,
+		convertToL3,
+		batchVlans(ctx, start, false),
+		updateNativeVlan,
+	}); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("device_id").(string))
+	return resourceMetalDeviceNetworkRead(ctx, d, meta)
+}
+
+func resourceMetalDeviceNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	device, _, err := client.Devices.Get(d.Id(), nil)
+	if err != nil {
+		err = friendlyError(err)
+		if isNotFound(err) || isForbidden(err) {
+			log.Printf("[WARN] Device (%s) for network configuration not accessible, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+		return err
+	}
+
+	// imported resources start with all the device ports
+	names := []string{}
+	for _, raw := range d.Get("port").([]interface{}) {
+		names = append(names, raw.(map[string]interface{})["name"].(string))
+	}
+	if len(names) == 0 {
+		for _, p := range device.NetworkPorts {
+			names = append(names, p.Name)
+		}
+	}
+
+	ports := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		devPort, err := device.GetPortByName(name)
+		if err != nil {
+			return err
+		}
+		cpr := &ClientPortResource{Client: client, Port: devPort}
+		if err := refreshPort(cpr); err != nil {
+			return friendlyError(err)
+		}
+		port := cpr.Port
+
+		vlans := []string{}
+		for _, n := range port.AttachedVirtualNetworks {
+			vlans = append(vlans, n.ID)
+		}
+		nativeVlan := ""
+		if port.NativeVirtualNetwork != nil {
+			nativeVlan = port.NativeVirtualNetwork.ID
+		}
+		ports = append(ports, map[string]interface{}{
+			"name":           port.Name,
+			"port_id":        port.ID,
+			"bonded":         port.Data.Bonded,
+			"layer2":         contains(l2Types, port.NetworkType),
+			"native_vlan_id": nativeVlan,
+			"vlan_ids":       vlans,
+			"network_type":   port.NetworkType,
+		})
+	}
+
+	return setMap(d, map[string]interface{}{
+		"device_id":    device.ID,
+		"network_type": device.GetNetworkType(),
+		"port":         ports,
+	})
+}
+
+func resourceMetalDeviceNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	resetRaw, resetOk := d.GetOk("reset_on_delete")
+	if !resetOk || !resetRaw.(bool) {
+		return nil
+	}
+
+	start := time.Now()
+	portBlocks := []interface{}{}
+	for _, raw := range d.Get("port").([]interface{}) {
+		portBlocks = append(portBlocks, map[string]interface{}{
+			"name":           raw.(map[string]interface{})["name"],
+			"bonded":         true,
+			"layer2":         false,
+			"native_vlan_id": "",
+			"vlan_ids":       schema.NewSet(schema.HashString, nil),
+		})
+	}
+	cprs, err := getDeviceNetworkPorts(d, meta, portBlocks)
+	if err != nil {
+		err = friendlyError(err)
+		if isNotFound(err) || isForbidden(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := convergeDevicePorts(cprs, [](func(*ClientPortResource) error){
+		batchVlans(ctx, start, true),
+		makeBond,
+		convertToL3,
+	}); err != nil {
+		return err
+	}
+	for _, cpr := range cprs {
+		if warn := portProperlyDestroyed(cpr.Port); warn != nil {
+			log.Printf("[WARN] %s\n", warn)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func confAccMetalDeviceNetwork_base(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_project" "test" {
+    name = "tfacc-device-network-test-%s"
+}
+
+resource "equinix_metal_device" "test" {
+  hostname         = "tfacc-metal-device-network-test"
+  plan             = local.plan
+  metro            = local.metro
+  operating_system = local.os
+  billing_cycle    = "hourly"
+  project_id       = equinix_metal_project.test.id
+  termination_time = "%s"
+}
+
+resource "equinix_metal_vlan" "test1" {
+  description = "tfacc-vlan test1"
+  metro       = equinix_metal_device.test.metro
+  project_id  = equinix_metal_project.test.id
+}
+
+resource "equinix_metal_vlan" "test2" {
+  description = "tfacc-vlan test2"
+  metro       = equinix_metal_device.test.metro
+  project_id  = equinix_metal_project.test.id
+}
+`, confAccMetalDevice_base(preferable_plans, preferable_metros, preferable_os), name, testDeviceTerminationTime())
+}
+
+func confAccMetalDeviceNetwork_hybridUnbonded(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_device_network" "test" {
+  device_id       = equinix_metal_device.test.id
+  reset_on_delete = true
+
+  port {
+    name   = "bond0"
+    bonded = true
+  }
+
+  port {
+    name           = "eth1"
+    bonded         = false
+    vlan_ids       = [equinix_metal_vlan.test1.id, equinix_metal_vlan.test2.id]
+    native_vlan_id = equinix_metal_vlan.test1.id
+  }
+}
+`, confAccMetalDeviceNetwork_base(name))
+}
+
+func confAccMetalDeviceNetwork_layer2Bonded(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_device_network" "test" {
+  device_id       = equinix_metal_device.test.id
+  reset_on_delete = true
+
+  port {
+    name     = "bond0"
+    bonded   = true
+    layer2   = true
+    vlan_ids = [equinix_metal_vlan.test1.id]
+  }
+
+  port {
+    name   = "eth1"
+    bonded = true
+  }
+}
+`, confAccMetalDeviceNetwork_base(name))
+}
+
+func TestAccMetalDeviceNetwork_hybridToLayer2(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalDeviceNetworkDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: confAccMetalDeviceNetwork_hybridUnbonded(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "network_type", "hybrid"),
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "port.1.bonded", "false"),
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "port.1.vlan_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_device_network.test", "port.1.native_vlan_id",
+						"equinix_metal_vlan.test1", "id"),
+				),
+			},
+			{
+				Config: confAccMetalDeviceNetwork_layer2Bonded(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "network_type", "layer2-bonded"),
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "port.0.network_type", "layer2-bonded"),
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "port.0.vlan_ids.#", "1"),
+					resource.TestCheckResourceAttr("equinix_metal_device_network.test", "port.1.vlan_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "equinix_metal_device_network.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reset_on_delete", "port"},
+			},
+		},
+	})
+}
+
+func testAccMetalDeviceNetworkDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).metal
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "equinix_metal_device_network" || rs.Primary.Attributes["reset_on_delete"] != "true" {
+			continue
+		}
+		for i := 0; i < 2; i++ {
+			pid := rs.Primary.Attributes[fmt.Sprintf("port.%d.port_id", i)]
+			p, _, err := client.Ports.Get(pid, nil)
+			if err != nil {
+				return fmt.Errorf("Error getting port %s during destroy check", pid)
+			}
+			if err = portProperlyDestroyed(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
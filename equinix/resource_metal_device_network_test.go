@@ -0,0 +1,37 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalDeviceNetwork_portResourceDataFromBlock(t *testing.T) {
+	// given
+	block := map[string]interface{}{
+		"name":           "eth1",
+		"bonded":         false,
+		"layer2":         false,
+		"native_vlan_id": "vlan-1",
+		"vlan_ids":       schema.NewSet(schema.HashString, []interface{}{"vlan-1", "vlan-2"}),
+	}
+	bond := &packngo.Port{ID: "bond-port", Type: "NetworkBondPort"}
+	eth := &packngo.Port{ID: "eth-port", Type: "NetworkPort"}
+	// when
+	bondData, bondErr := portResourceDataFromBlock(block, bond)
+	ethData, ethErr := portResourceDataFromBlock(block, eth)
+	// then
+	assert.Nil(t, bondErr)
+	assert.Nil(t, ethErr)
+	_, bondL2Ok := bondData.GetOkExists("layer2")
+	assert.True(t, bondL2Ok, "layer2 is set on bond ports")
+	_, ethL2Ok := ethData.GetOkExists("layer2")
+	assert.False(t, ethL2Ok, "layer2 is not set on physical ports")
+	assert.Equal(t, "eth-port", ethData.Get("port_id"))
+	assert.False(t, ethData.Get("bonded").(bool))
+	assert.Equal(t, "vlan-1", getSpecifiedNative(ethData))
+	assert.ElementsMatch(t, []string{"vlan-1", "vlan-2"}, specifiedVlanIds(ethData))
+	assert.Nil(t, portSanityChecks(&ClientPortResource{Port: eth, Resource: ethData}))
+}
@@ -0,0 +1,164 @@
+package equinix
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+func resourceMetalElasticIPAssignment() *schema.Resource {
+	elasticIPSchema := metalIPResourceComputedFields()
+	elasticIPSchema["device_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "ID of the device the elastic IP is assigned to. Changing it moves the IP to the other device",
+	}
+	elasticIPSchema["cidr_notation"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		Description:  "Elastic IP address or subnet in CIDR notation, from a public_ipv4 or global_ipv4 reserved IP block",
+		ValidateFunc: validation.IsCIDR,
+	}
+	return &schema.Resource{
+		Create: resourceMetalElasticIPAssignmentCreate,
+		Read:   resourceMetalElasticIPAssignmentRead,
+		Update: resourceMetalElasticIPAssignmentUpdate,
+		Delete: resourceMetalElasticIPAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: elasticIPSchema,
+	}
+}
+
+func resourceMetalElasticIPAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	deviceID := d.Get("device_id").(string)
+	ipa := d.Get("cidr_notation").(string)
+	assignment, _, err := client.DeviceIPs.Assign(deviceID, &packngo.AddressStruct{Address: ipa})
+	if err != nil {
+		return fmt.Errorf("error assigning address %s to device %s: %s", ipa, deviceID, friendlyError(err))
+	}
+
+	d.SetId(assignment.ID)
+
+	return resourceMetalElasticIPAssignmentRead(d, meta)
+}
+
+func resourceMetalElasticIPAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+	assignment, _, err := client.DeviceIPs.Get(d.Id(), nil)
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the elastic IP was unassigned out of band, mark as succesfully gone.
+		if isNotFound(err) {
+			log.Printf("[WARN] Elastic IP assignment (%q) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.SetId(assignment.ID)
+	return setMap(d, map[string]interface{}{
+		"address":        assignment.Address,
+		"gateway":        assignment.Gateway,
+		"network":        assignment.Network,
+		"netmask":        assignment.Netmask,
+		"address_family": assignment.AddressFamily,
+		"cidr":           assignment.CIDR,
+		"public":         assignment.Public,
+		"management":     assignment.Management,
+		"manageable":     assignment.Manageable,
+		"global":         assignment.Global,
+		"device_id":      path.Base(assignment.AssignedTo.Href),
+		"cidr_notation":  fmt.Sprintf("%s/%d", assignment.Network, assignment.CIDR),
+	})
+}
+
+func resourceMetalElasticIPAssignmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	if d.HasChange("device_id") {
+		o, n := d.GetChange("device_id")
+		oldDeviceID, newDeviceID := o.(string), n.(string)
+		ipa := d.Get("cidr_notation").(string)
+
+		assignment, _, err := client.DeviceIPs.Get(d.Id(), &packngo.GetOptions{Includes: []string{"metro"}})
+		if err != nil {
+			return friendlyError(err)
+		}
+		// check what can be checked before the IP is taken away from the
+		// old device, global IPs can be assigned to a device in any metro
+		if !assignment.Global && assignment.Metro != nil {
+			dev, _, err := client.Devices.Get(newDeviceID, &packngo.GetOptions{Includes: []string{"metro"}})
+			if err != nil {
+				return friendlyError(err)
+			}
+			if dev.Metro == nil || !strings.EqualFold(dev.Metro.Code, assignment.Metro.Code) {
+				return fmt.Errorf("elastic IP %s is in metro %s and can't be assigned to device %s in another metro", ipa, assignment.Metro.Code, newDeviceID)
+			}
+		}
+
+		newAssignment, err := reassignMetalElasticIP(client, d.Id(), ipa, oldDeviceID, newDeviceID)
+		if newAssignment != nil {
+			d.SetId(newAssignment.ID)
+		}
+		if err != nil {
+			// keep the old device_id in state, the IP is either still or
+			// again assigned to the old device
+			d.Partial(true)
+			return err
+		}
+	}
+
+	return resourceMetalElasticIPAssignmentRead(d, meta)
+}
+
+// reassignMetalElasticIP moves the elastic IP from the old device to the new
+// one. If the IP can't be assigned to the new device, it is assigned back to
+// the old device. The returned assignment is the one the IP ended up in.
+func reassignMetalElasticIP(client *packngo.Client, assignmentID, ipa, oldDeviceID, newDeviceID string) (*packngo.IPAddressAssignment, error) {
+	resp, err := client.DeviceIPs.Unassign(assignmentID)
+	if ignoreResponseErrors(httpNotFound)(resp, err) != nil {
+		return nil, fmt.Errorf("error unassigning address %s from device %s: %s", ipa, oldDeviceID, friendlyError(err))
+	}
+
+	assignment, _, err := client.DeviceIPs.Assign(newDeviceID, &packngo.AddressStruct{Address: ipa})
+	if err == nil {
+		return assignment, nil
+	}
+	assignErr := fmt.Errorf("error assigning address %s to device %s: %s", ipa, newDeviceID, friendlyError(err))
+
+	log.Printf("[WARN] %s, assigning it back to device %s", assignErr, oldDeviceID)
+	rollback, _, err := client.DeviceIPs.Assign(oldDeviceID, &packngo.AddressStruct{Address: ipa})
+	if err != nil {
+		return nil, fmt.Errorf("%s; rollback to device %s failed: %s", assignErr, oldDeviceID, friendlyError(err))
+	}
+	return rollback, assignErr
+}
+
+func resourceMetalElasticIPAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	resp, err := client.DeviceIPs.Unassign(d.Id())
+	if ignoreResponseErrors(httpForbidden, httpNotFound)(resp, err) != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,102 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMetalElasticIPAssignment_reassign(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalElasticIPAssignmentCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalElasticIPAssignmentConfig(rs, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_elastic_ip_assignment.test", "public", "true"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_elastic_ip_assignment.test", "device_id",
+						"equinix_metal_device.first", "id"),
+				),
+			},
+			{
+				Config: testAccMetalElasticIPAssignmentConfig(rs, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_elastic_ip_assignment.test", "device_id",
+						"equinix_metal_device.second", "id"),
+				),
+			},
+			{
+				ResourceName:      "equinix_metal_elastic_ip_assignment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMetalElasticIPAssignmentConfig(name, device string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "equinix_metal_project" "test" {
+    name = "tfacc-elastic_ip_assignment-%s"
+}
+
+resource "equinix_metal_device" "first" {
+  hostname         = "tfacc-device-elastic-ip-first"
+  plan             = local.plan
+  metro            = local.metro
+  operating_system = local.os
+  billing_cycle    = "hourly"
+  project_id       = equinix_metal_project.test.id
+  termination_time = "%s"
+}
+
+resource "equinix_metal_device" "second" {
+  hostname         = "tfacc-device-elastic-ip-second"
+  plan             = local.plan
+  metro            = local.metro
+  operating_system = local.os
+  billing_cycle    = "hourly"
+  project_id       = equinix_metal_project.test.id
+  termination_time = "%s"
+}
+
+resource "equinix_metal_reserved_ip_block" "test" {
+    project_id = equinix_metal_project.test.id
+    metro      = equinix_metal_device.first.metro
+    quantity   = 2
+}
+
+resource "equinix_metal_elastic_ip_assignment" "test" {
+	device_id = equinix_metal_device.%s.id
+	cidr_notation = "${cidrhost(equinix_metal_reserved_ip_block.test.cidr_notation,0)}/32"
+}`, confAccMetalDevice_base(preferable_plans, preferable_metros, preferable_os), name,
+		testDeviceTerminationTime(), testDeviceTerminationTime(), device)
+}
+
+func testAccMetalElasticIPAssignmentCheckDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).metal
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "equinix_metal_elastic_ip_assignment" {
+			continue
+		}
+		if _, _, err := client.DeviceIPs.Get(rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("Metal elastic IP assignment still exists")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMetalHardwareReservation() *schema.Resource {
+	sch := hardwareReservationRecordSchema()
+	delete(sch, "id")
+	sch["hardware_reservation_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "ID of the hardware reservation to manage",
+	}
+	sch["project_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "UUID of the project the reservation is assigned to. Changing it moves the reservation to the other project",
+	}
+	sch["wait_for_provisionable"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Wait until the reservation is provisionable after it is moved to the project. Spare reservations are never waited for",
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout:   diagnosticsWrapper(resourceMetalHardwareReservationRead),
+		CreateWithoutTimeout: diagnosticsWrapper(resourceMetalHardwareReservationCreate),
+		UpdateWithoutTimeout: diagnosticsWrapper(resourceMetalHardwareReservationUpdate),
+		DeleteWithoutTimeout: diagnosticsWrapper(resourceMetalHardwareReservationDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("hardware_reservation_id", d.Id())
+				d.Set("wait_for_provisionable", true)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: sch,
+	}
+}
+
+func resourceMetalHardwareReservationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	id := d.Get("hardware_reservation_id").(string)
+	hr, resp, err := client.HardwareReservationsApi.FindHardwareReservationById(ctx, id).
+		Include([]string{"project"}).Execute()
+	if err != nil {
+		return friendlyErrorForMetalGo(err, resp)
+	}
+
+	d.SetId(hr.GetId())
+
+	if hr.Project.GetId() != d.Get("project_id").(string) {
+		if err := moveMetalHardwareReservation(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceMetalHardwareReservationRead(ctx, d, meta)
+}
+
+func resourceMetalHardwareReservationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("project_id") {
+		if err := moveMetalHardwareReservation(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceMetalHardwareReservationRead(ctx, d, meta)
+}
+
+// moveMetalHardwareReservation moves the reservation to the configured
+// project and waits until it is provisionable there.
+func moveMetalHardwareReservation(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	projectID := d.Get("project_id").(string)
+	moveRequest := metalv1.MoveHardwareReservationRequest{ProjectId: &projectID}
+	hr, resp, err := client.HardwareReservationsApi.MoveHardwareReservation(ctx, d.Id()).
+		MoveHardwareReservationRequest(moveRequest).Execute()
+	if err != nil {
+		return friendlyErrorForMetalGo(err, resp)
+	}
+
+	// spare reservations aren't provisionable until they are activated
+	if !d.Get("wait_for_provisionable").(bool) || hr.GetSpare() {
+		return nil
+	}
+
+	if err := waitUntilReservationProvisionable(ctx, meta.(*Config).metal, d.Id(), "", 5*time.Second, timeout, 5*time.Second); err != nil {
+		return fmt.Errorf("error waiting for hardware reservation %s to be provisionable in project %s: %s", d.Id(), projectID, err)
+	}
+	return nil
+}
+
+func resourceMetalHardwareReservationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	hr, resp, err := client.HardwareReservationsApi.FindHardwareReservationById(ctx, d.Id()).
+		Include(hardwareReservationIncludes).Execute()
+	if err != nil {
+		err = friendlyErrorForMetalGo(err, resp)
+		if isNotFound(err) || isForbidden(err) {
+			log.Printf("[WARN] Hardware reservation (%s) not accessible, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+		return err
+	}
+
+	m := getHardwareReservationMap(*hr)
+	delete(m, "id")
+	m["hardware_reservation_id"] = hr.GetId()
+	return setMap(d, m)
+}
+
+func resourceMetalHardwareReservationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	// hardware reservations can't be deleted, they stay in the project they
+	// were last moved to
+	log.Printf("[WARN] Hardware reservation (%s) is only removed from state, it stays in project %s", d.Id(), d.Get("project_id").(string))
+	d.SetId("")
+	return nil
+}
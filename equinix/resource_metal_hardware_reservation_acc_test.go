@@ -0,0 +1,79 @@
+package equinix
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const metalHardwareReservationIDEnvVar = "TF_ACC_METAL_HARDWARE_RESERVATION_ID"
+
+func TestAccMetalHardwareReservation_move(t *testing.T) {
+	hrID := os.Getenv(metalHardwareReservationIDEnvVar)
+	if hrID == "" {
+		t.Skipf("%s must be set to the ID of an unused hardware reservation", metalHardwareReservationIDEnvVar)
+	}
+	rs := acctest.RandString(10)
+	r := "equinix_metal_hardware_reservation.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalHardwareReservationConfig(rs, hrID, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(r, "project_id", "equinix_metal_project.first", "id"),
+					resource.TestCheckResourceAttr(r, "hardware_reservation_id", hrID),
+					resource.TestCheckResourceAttrSet(r, "plan"),
+					resource.TestCheckResourceAttrPair(
+						"data.equinix_metal_hardware_reservations.test", "hardware_reservations.0.id",
+						r, "id"),
+				),
+			},
+			{
+				Config: testAccMetalHardwareReservationConfig(rs, hrID, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(r, "project_id", "equinix_metal_project.second", "id"),
+					resource.TestCheckResourceAttr(r, "hardware_reservation_id", hrID),
+				),
+			},
+			{
+				ResourceName:            r,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_provisionable"},
+			},
+		},
+	})
+}
+
+func testAccMetalHardwareReservationConfig(name, hrID, project string) string {
+	return fmt.Sprintf(`
+resource "equinix_metal_project" "first" {
+    name = "tfacc-hwres-%[1]s-1"
+}
+
+resource "equinix_metal_project" "second" {
+    name = "tfacc-hwres-%[1]s-2"
+}
+
+resource "equinix_metal_hardware_reservation" "test" {
+    hardware_reservation_id = "%[2]s"
+    project_id              = equinix_metal_project.%[3]s.id
+}
+
+data "equinix_metal_hardware_reservations" "test" {
+    project_id = equinix_metal_hardware_reservation.test.project_id
+
+    filter {
+        attribute = "id"
+        values    = [equinix_metal_hardware_reservation.test.id]
+    }
+}
+`, name, hrID, project)
+}
@@ -1,11 +1,15 @@
 package equinix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"path"
 	"strings"
+	"time"
 
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/packethost/packngo"
@@ -28,6 +32,7 @@ func resourceMetalOrganizationMember() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceMetalOrganizationMemberCreate,
 		Read:   resourceMetalOrganizationMemberRead,
+		Update: resourceMetalOrganizationMemberUpdate,
 		Delete: resourceMetalOrganizationMemberDelete,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -75,8 +80,6 @@ func resourceMetalOrganizationMember() *schema.Resource {
 					Type:         schema.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
-				// TODO: Update should be supported. packngo.InvitationService does not offer an Update	method.
-				ForceNew: true,
 			},
 			"nonce": {
 				Type:        schema.TypeString,
@@ -87,7 +90,6 @@ func resourceMetalOrganizationMember() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "A message to the invitee (only used during the invitation stage)",
 				Optional:    true,
-				ForceNew:    true,
 			},
 			"created": {
 				Type:        schema.TypeString,
@@ -104,34 +106,96 @@ func resourceMetalOrganizationMember() *schema.Resource {
 				Description: "Organization roles (owner, collaborator, limited_collaborator, billing)",
 				Required:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				// TODO: Update should be supported. packngo.InvitationService does not offer an Update	method.
-				ForceNew: true,
 			},
 			"state": {
 				Type:        schema.TypeString,
 				Description: "The state of the membership ('invited' when an invitation is open, 'active' when the user is an organization member)",
 				Computed:    true,
 			},
+			"reinvite_after": {
+				Type:         schema.TypeString,
+				Description:  "Resend the invitation if it was not accepted within this duration since it was last sent, e.g. 168h",
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
 		},
+		CustomizeDiff: customdiff.Sequence(
+			// owner and billing are organization wide roles, which can only
+			// be granted with a new invitation
+			customdiff.ForceNewIfChange("roles", func(ctx context.Context, old, new, meta interface{}) bool {
+				for _, role := range organizationWideRoles {
+					if old.(*schema.Set).Contains(role) != new.(*schema.Set).Contains(role) {
+						return true
+					}
+				}
+				return false
+			}),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.Id() == "" || d.Get("state").(string) != "invited" {
+					return nil
+				}
+				updated, err := time.Parse(invitationTimestampLayout, d.Get("updated").(string))
+				if err != nil {
+					return nil
+				}
+				if invitationResendDue(updated, d.Get("reinvite_after").(string), time.Now()) {
+					return d.SetNewComputed("updated")
+				}
+				return nil
+			},
+		),
 	}
 }
 
-func resourceMetalOrganizationMemberCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).metal
+var organizationWideRoles = []string{"owner", "billing"}
 
-	email := d.Get("invitee").(string)
+// invitationTimestampLayout is the layout of the invitation created and
+// updated attributes, which are stored with time.Time.String
+const invitationTimestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+func validateDuration(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration, e.g. 168h: %s", k, err))
+	}
+	return
+}
+
+// invitationResendDue reports whether an invitation last sent at updated
+// should be resent because it wasn't accepted within reinviteAfter.
+func invitationResendDue(updated time.Time, reinviteAfter string, now time.Time) bool {
+	if reinviteAfter == "" {
+		return false
+	}
+	after, err := time.ParseDuration(reinviteAfter)
+	if err != nil {
+		return false
+	}
+	return now.Sub(updated) > after
+}
+
+func createMetalOrganizationInvitation(d *schema.ResourceData, client *packngo.Client) error {
 	createRequest := &packngo.InvitationCreateRequest{
-		Invitee:     email,
+		Invitee:     d.Get("invitee").(string),
 		Roles:       convertStringArr(d.Get("roles").(*schema.Set).List()),
 		ProjectsIDs: convertStringArr(d.Get("projects_ids").(*schema.Set).List()),
 		Message:     strings.TrimSpace(d.Get("message").(string)),
 	}
 
-	orgID := d.Get("organization_id").(string)
-	_, _, err := client.Invitations.Create(orgID, createRequest, nil)
+	_, _, err := client.Invitations.Create(d.Get("organization_id").(string), createRequest, nil)
 	if err != nil {
 		return friendlyError(err)
 	}
+	return nil
+}
+
+func resourceMetalOrganizationMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).metal
+
+	email := d.Get("invitee").(string)
+	orgID := d.Get("organization_id").(string)
+	if err := createMetalOrganizationInvitation(d, client); err != nil {
+		return err
+	}
 
 	d.SetId(fmt.Sprintf("%s:%s", email, orgID))
 
@@ -193,6 +257,18 @@ func resourceMetalOrganizationMemberRead(d *schema.ResourceData, meta interface{
 		for _, project := range member.Member.Projects {
 			projectIDs = append(projectIDs, path.Base(project.URL))
 		}
+		// projects added to an accepted member are granted with an
+		// invitation, which counts as access until it is accepted
+		for _, inv := range invitations {
+			if inv.Invitee != invitee {
+				continue
+			}
+			for _, project := range inv.Projects {
+				if id := path.Base(project.Href); !contains(projectIDs, id) {
+					projectIDs = append(projectIDs, id)
+				}
+			}
+		}
 		return setMap(d, map[string]interface{}{
 			"state":           "active",
 			"roles":           stringArrToIfArr(member.Member.Roles),
@@ -218,6 +294,102 @@ func resourceMetalOrganizationMemberRead(d *schema.ResourceData, meta interface{
 	return fmt.Errorf("got an invalid member object")
 }
 
+func resourceMetalOrganizationMemberUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).metal
+	orgID := d.Get("organization_id").(string)
+
+	listOpts := &packngo.ListOptions{Includes: []string{"user"}}
+	invitations, _, err := client.Invitations.List(orgID, listOpts)
+	if err != nil {
+		return friendlyError(err)
+	}
+	members, _, err := client.Members.List(orgID, &packngo.GetOptions{Includes: []string{"user"}})
+	if err != nil {
+		return friendlyError(err)
+	}
+	member, err := findMember(d.Get("invitee").(string), members, invitations)
+	if err != nil {
+		return fmt.Errorf("member %s not found in organization %s", d.Get("invitee").(string), orgID)
+	}
+
+	if member.isInvitation() {
+		if d.HasChanges("roles", "projects_ids", "message") {
+			// open invitations can't be updated, they are replaced
+			if _, err := client.Invitations.Delete(member.Invitation.ID); err != nil {
+				return friendlyError(err)
+			}
+			if err := createMetalOrganizationInvitation(d, client); err != nil {
+				return err
+			}
+		} else if member.Invitation.UpdatedAt != nil &&
+			invitationResendDue(member.Invitation.UpdatedAt.Time, d.Get("reinvite_after").(string), time.Now()) {
+			log.Printf("[DEBUG] Resending invitation of %s to organization %s", member.Invitation.Invitee, orgID)
+			if _, _, err := client.Invitations.Resend(member.Invitation.ID); err != nil {
+				return friendlyError(err)
+			}
+		}
+	} else if member.isMember() && d.HasChanges("roles", "projects_ids") {
+		if err := updateMetalOrganizationMembership(d, meta, member.Member); err != nil {
+			return err
+		}
+	}
+
+	return resourceMetalOrganizationMemberRead(d, meta)
+}
+
+// updateMetalOrganizationMembership applies the roles and projects of an
+// accepted member through the member's project memberships. Access to the
+// removed projects is revoked, the roles of the remaining projects are
+// updated and the member is invited to the added projects.
+func updateMetalOrganizationMembership(d *schema.ResourceData, meta interface{}, mbr *packngo.Member) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+	ctx := context.Background()
+
+	o, n := d.GetChange("projects_ids")
+	oldProjects := convertStringArr(o.(*schema.Set).List())
+	newProjects := convertStringArr(n.(*schema.Set).List())
+	roles := convertStringArr(d.Get("roles").(*schema.Set).List())
+
+	for _, projectID := range oldProjects {
+		memberships, resp, err := client.ProjectsApi.FindProjectMemberships(ctx, projectID).Search(mbr.User.Email).Execute()
+		if err != nil {
+			return friendlyErrorForMetalGo(err, resp)
+		}
+		for _, m := range memberships.GetMemberships() {
+			if m.User == nil || path.Base(m.User.GetHref()) != mbr.User.ID {
+				continue
+			}
+			if contains(newProjects, projectID) {
+				if !d.HasChange("roles") {
+					continue
+				}
+				input := metalv1.MembershipInput{Role: roles}
+				if _, resp, err := client.MembershipsApi.UpdateMembership(ctx, m.GetId()).MembershipInput(input).Execute(); err != nil {
+					return friendlyErrorForMetalGo(err, resp)
+				}
+			} else if resp, err := client.MembershipsApi.DeleteMembership(ctx, m.GetId()).Execute(); err != nil {
+				if err = friendlyErrorForMetalGo(err, resp); !isNotFound(err) {
+					return err
+				}
+			}
+		}
+	}
+
+	if added := difference(newProjects, oldProjects); len(added) > 0 {
+		createRequest := &packngo.InvitationCreateRequest{
+			Invitee:     mbr.User.Email,
+			Roles:       roles,
+			ProjectsIDs: added,
+			Message:     strings.TrimSpace(d.Get("message").(string)),
+		}
+		if _, _, err := meta.(*Config).metal.Invitations.Create(d.Get("organization_id").(string), createRequest, nil); err != nil {
+			return friendlyError(err)
+		}
+	}
+	return nil
+}
+
 func resourceMetalOrganizationMemberDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Config).metal
 
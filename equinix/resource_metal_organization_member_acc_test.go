@@ -74,6 +74,17 @@ func TestAccResourceMetalOrganizationMember_basic(t *testing.T) {
 				),
 				ImportStateVerify: true,
 			},
+			{
+				Config: testAccResourceMetalOrganizationMember_basic(rInt) + testAccResourceMetalOrganizationMember_memberCollaborator(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_organization_member.member", "state",
+						"invited"),
+					resource.TestCheckTypeSetElemAttr(
+						"equinix_metal_organization_member.member", "roles.*",
+						"collaborator"),
+				),
+			},
 			{
 				Config:  testAccResourceMetalOrganizationMember_basic(rInt),
 				Destroy: true,
@@ -124,3 +135,16 @@ resource "equinix_metal_organization_member" "member" {
 }
 `
 }
+
+func testAccResourceMetalOrganizationMember_memberCollaborator() string {
+	return `
+resource "equinix_metal_organization_member" "member" {
+    invitee = "tfacc.testing.member@equinixmetal.com"
+    roles = ["collaborator"]
+    projects_ids = [equinix_metal_project.test.id]
+    organization_id = equinix_metal_organization.test.id
+	reinvite_after = "168h"
+	message = "This invitation was sent by the github.com/equinix/terraform-provider-equinix acceptance tests to test equinix_metal_organization_member resources."
+}
+`
+}
@@ -0,0 +1,33 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalOrganizationMember_invitationResendDue(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-8 * 24 * time.Hour)
+	// when
+	recentDue := invitationResendDue(recent, "168h", now)
+	oldDue := invitationResendDue(old, "168h", now)
+	unsetDue := invitationResendDue(old, "", now)
+	// then
+	assert.False(t, recentDue, "invitation sent within reinvite_after is not resent")
+	assert.True(t, oldDue, "invitation not accepted within reinvite_after is resent")
+	assert.False(t, unsetDue, "invitations are not resent without reinvite_after")
+}
+
+func TestMetalOrganizationMember_invitationTimestampLayout(t *testing.T) {
+	// given
+	updated := time.Date(2023, 6, 15, 12, 30, 15, 123000000, time.UTC)
+	// when
+	parsed, err := time.Parse(invitationTimestampLayout, updated.String())
+	// then
+	assert.Nil(t, err)
+	assert.True(t, updated.Equal(parsed))
+}
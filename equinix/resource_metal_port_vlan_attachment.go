@@ -132,13 +132,9 @@ func resourceMetalPortVlanAttachmentCreate(d *schema.ResourceData, meta interfac
 
 		par.VirtualNetworkID = vlanID
 
-		// Equinix Metal doesn't allow multiple VLANs to be assigned
-		// to the same port at the same time
-		lockId := "vlan-attachment-" + port.ID
-		metalMutexKV.Lock(lockId)
-		defer metalMutexKV.Unlock(lockId)
-
-		_, _, err = client.DevicePorts.Assign(par)
+		// attachments to the same port created in the same apply are
+		// gathered and assigned in a single VLAN assignment batch
+		err = metalPortVlanBatcher.Assign(client, port.ID, vlanID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return err
 		}
@@ -148,7 +144,12 @@ func resourceMetalPortVlanAttachmentCreate(d *schema.ResourceData, meta interfac
 
 	native := d.Get("native").(bool)
 	if native {
+		// the native VLAN change must not overlap with a VLAN assignment
+		// batch of the same port
+		lockId := "vlan-attachment-" + port.ID
+		metalMutexKV.Lock(lockId)
 		_, _, err = client.DevicePorts.AssignNative(par)
+		metalMutexKV.Unlock(lockId)
 		if err != nil {
 			return err
 		}
@@ -217,6 +218,9 @@ func resourceMetalPortVlanAttachmentUpdate(d *schema.ResourceData, meta interfac
 	if d.HasChange("native") {
 		native := d.Get("native").(bool)
 		portID := d.Get("port_id").(string)
+		lockId := "vlan-attachment-" + portID
+		metalMutexKV.Lock(lockId)
+		defer metalMutexKV.Unlock(lockId)
 		if native {
 			vlanID := d.Get("vlan_id").(string)
 			par := &packngo.PortAssignRequest{PortID: portID, VirtualNetworkID: vlanID}
@@ -5,7 +5,9 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/packethost/packngo"
@@ -22,6 +24,10 @@ func resourceMetalProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -122,6 +128,50 @@ func expandBGPConfig(d *schema.ResourceData) packngo.CreateBGPConfigRequest {
 	return bgpCreateRequest
 }
 
+// requestBGPConfig submits the project BGP configuration to the BGP config
+// request endpoint and waits until the API reflects the requested settings.
+// The same endpoint is used to enable BGP and to change the deployment type,
+// ASN or MD5 password of an existing configuration.
+func requestBGPConfig(client *packngo.Client, projectID string, bgpCR packngo.CreateBGPConfigRequest, timeout time.Duration) error {
+	if _, err := client.BGPConfig.Create(projectID, bgpCR); err != nil {
+		return friendlyError(err)
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"pending"},
+		// global deployments stay "requested" until reviewed by Equinix Metal engineers
+		Target:     []string{"enabled", "requested"},
+		Refresh:    bgpConfigStateRefreshFunc(client, projectID, bgpCR),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for BGP config of project (%s) to be updated: %s", projectID, err)
+	}
+	return nil
+}
+
+func bgpConfigStateRefreshFunc(client *packngo.Client, projectID string, bgpCR packngo.CreateBGPConfigRequest) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		bgpConf, _, err := client.BGPConfig.Get(projectID, nil)
+		if err != nil {
+			err = friendlyError(err)
+			if isNotFound(err) {
+				return &packngo.BGPConfig{}, "pending", nil
+			}
+			return nil, "", err
+		}
+		// the config is reported as pending until the requested settings are visible
+		if bgpConf == nil || bgpConf.ID == "" ||
+			bgpConf.Asn != bgpCR.Asn ||
+			bgpConf.DeploymentType != bgpCR.DeploymentType {
+			return bgpConf, "pending", nil
+		}
+		return bgpConf, bgpConf.Status, nil
+	}
+}
+
 func resourceMetalProjectCreate(d *schema.ResourceData, meta interface{}) error {
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
@@ -141,9 +191,8 @@ func resourceMetalProjectCreate(d *schema.ResourceData, meta interface{}) error
 	_, hasBGPConfig := d.GetOk("bgp_config")
 	if hasBGPConfig {
 		bgpCR := expandBGPConfig(d)
-		_, err := client.BGPConfig.Create(project.ID, bgpCR)
-		if err != nil {
-			return friendlyError(err)
+		if err := requestBGPConfig(client, project.ID, bgpCR, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
 		}
 	}
 
@@ -247,15 +296,20 @@ func resourceMetalProjectUpdate(d *schema.ResourceData, meta interface{}) error
 		pBT := d.Get("backend_transfer").(bool)
 		updateRequest.BackendTransfer = &pBT
 	}
+	if d.HasChanges("name", "payment_method_id", "backend_transfer") {
+		_, _, err := client.Projects.Update(d.Id(), updateRequest)
+		if err != nil {
+			return friendlyError(err)
+		}
+	}
 	if d.HasChange("bgp_config") {
 		o, n := d.GetChange("bgp_config")
 		oldarr := o.([]interface{})
 		newarr := n.([]interface{})
 		if len(newarr) == 1 {
-			bgpCreateRequest := expandBGPConfig(d)
-			_, err := client.BGPConfig.Create(d.Id(), bgpCreateRequest)
-			if err != nil {
-				return friendlyError(err)
+			bgpCR := expandBGPConfig(d)
+			if err := requestBGPConfig(client, d.Id(), bgpCR, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
 			}
 		} else {
 			if len(oldarr) == 1 {
@@ -273,11 +327,6 @@ func resourceMetalProjectUpdate(d *schema.ResourceData, meta interface{}) error
 				return friendlyError(errStr)
 			}
 		}
-	} else {
-		_, _, err := client.Projects.Update(d.Id(), updateRequest)
-		if err != nil {
-			return friendlyError(err)
-		}
 	}
 
 	return resourceMetalProjectRead(d, meta)
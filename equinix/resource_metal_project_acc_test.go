@@ -298,7 +298,7 @@ func testAccCheckMetalSameProject(t *testing.T, before, after *packngo.Project)
 }
 
 func TestAccMetalProject_BGPUpdate(t *testing.T) {
-	var p1, p2, p3 packngo.Project
+	var p1, p2, p3, p4 packngo.Project
 	rInt := acctest.RandInt()
 	res := "equinix_metal_project.foobar"
 
@@ -337,6 +337,15 @@ func TestAccMetalProject_BGPUpdate(t *testing.T) {
 					testAccCheckMetalSameProject(t, &p2, &p3),
 				),
 			},
+			{
+				Config: testAccMetalProjectConfig_BGPASN(rInt, "fdsfsdf432G", 65001),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMetalProjectExists(res, &p4),
+					resource.TestCheckResourceAttr(res, "bgp_config.0.asn", "65001"),
+					resource.TestCheckResourceAttr(res, "bgp_config.0.status", "enabled"),
+					testAccCheckMetalSameProject(t, &p3, &p4),
+				),
+			},
 			{
 				Config:      testAccMetalProjectConfig_basic(rInt),
 				ExpectError: regexp.MustCompile("can not be removed"),
@@ -402,15 +411,19 @@ resource "equinix_metal_project" "foobar" {
 }
 
 func testAccMetalProjectConfig_BGP(r int, pass string) string {
+	return testAccMetalProjectConfig_BGPASN(r, pass, 65000)
+}
+
+func testAccMetalProjectConfig_BGPASN(r int, pass string, asn int) string {
 	return fmt.Sprintf(`
 resource "equinix_metal_project" "foobar" {
     name = "tfacc-project-%d"
 	bgp_config {
 		deployment_type = "local"
 		md5 = "%s"
-		asn = 65000
+		asn = %d
 	}
-}`, r, pass)
+}`, r, pass, asn)
 }
 
 func testAccMetalProjectConfig_organization(r string) string {
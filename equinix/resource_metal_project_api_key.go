@@ -1,7 +1,10 @@
 package equinix
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/packethost/packngo"
@@ -27,6 +30,56 @@ func schemaMetalAPIKey() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "API token for API clients",
 		},
+		"created": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the current API key was created",
+		},
+		"rotation": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Rotate the API key when the interval elapses or the keepers change. The previous key is kept for the grace period",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interval": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Maximum age of the API key, e.g. 2160h. The key is rotated on the first apply after it is older",
+						ValidateFunc: validateDuration,
+					},
+					"keepers": {
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Description: "Arbitrary map of values which rotate the API key when changed",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"grace_period": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "24h",
+						Description:  "How long the previous API key is kept after a rotation, e.g. 24h. It is deleted on the first apply after the grace period",
+						ValidateFunc: validateDuration,
+					},
+				},
+			},
+		},
+		"previous_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the previous API key, set during the grace period after a rotation",
+		},
+		"previous_token": {
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+			Description: "API token of the previous API key, set during the grace period after a rotation",
+		},
+		"previous_expires_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the grace period of the previous API key ends",
+		},
 	}
 }
 
@@ -39,36 +92,159 @@ func resourceMetalProjectAPIKey() *schema.Resource {
 		Description: "UUID of project which the new API key is scoped to",
 	}
 	return &schema.Resource{
-		Create: resourceMetalAPIKeyCreate,
-		Read:   resourceMetalAPIKeyRead,
-		Delete: resourceMetalAPIKeyDelete,
-		Schema: projectKeySchema,
+		Create:        resourceMetalAPIKeyCreate,
+		Read:          resourceMetalAPIKeyRead,
+		Update:        resourceMetalAPIKeyUpdate,
+		Delete:        resourceMetalAPIKeyDelete,
+		CustomizeDiff: resourceMetalAPIKeyRotationDiff,
+		Schema:        projectKeySchema,
+	}
+}
+
+func createMetalAPIKey(d *schema.ResourceData, client *packngo.Client) (*packngo.APIKey, error) {
+	createRequest := &packngo.APIKeyCreateRequest{
+		ProjectID:   projectIdFromResourceData(d),
+		ReadOnly:    d.Get("read_only").(bool),
+		Description: d.Get("description").(string),
+	}
+
+	apiKey, _, err := client.APIKeys.Create(createRequest)
+	if err != nil {
+		return nil, friendlyError(err)
 	}
+	return apiKey, nil
 }
 
 func resourceMetalAPIKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
 
-	projectId := ""
-
-	projectIdRaw, projectIdOk := d.GetOk("project_id")
-	if projectIdOk {
-		projectId = projectIdRaw.(string)
+	apiKey, err := createMetalAPIKey(d, client)
+	if err != nil {
+		return err
 	}
 
-	createRequest := &packngo.APIKeyCreateRequest{
-		ProjectID:   projectId,
-		ReadOnly:    d.Get("read_only").(bool),
-		Description: d.Get("description").(string),
+	d.SetId(apiKey.ID)
+
+	return resourceMetalAPIKeyRead(d, meta)
+}
+
+// apiKeyRotationDue reports whether the API key has to be rotated, either
+// because it is older than the rotation interval or because the keepers of an
+// existing rotation block changed.
+func apiKeyRotationDue(d interface {
+	Get(string) interface{}
+	GetChange(string) (interface{}, interface{})
+	HasChange(string) bool
+}, now time.Time) bool {
+	if len(d.Get("rotation").([]interface{})) == 0 {
+		return false
+	}
+	if oldRotation, _ := d.GetChange("rotation"); len(oldRotation.([]interface{})) > 0 && d.HasChange("rotation.0.keepers") {
+		return true
 	}
+	interval, err := time.ParseDuration(d.Get("rotation.0.interval").(string))
+	if err != nil {
+		return false
+	}
+	// the planned created attribute is unknown when a rotation is due
+	o, _ := d.GetChange("created")
+	created, err := time.Parse(time.RFC3339, o.(string))
+	if err != nil {
+		return false
+	}
+	return now.Sub(created) > interval
+}
 
-	apiKey, _, err := client.APIKeys.Create(createRequest)
+// apiKeyGraceExpired reports whether the previous API key kept after a
+// rotation is past its grace period.
+func apiKeyGraceExpired(previousExpiresAt string, now time.Time) bool {
+	expires, err := time.Parse(time.RFC3339, previousExpiresAt)
 	if err != nil {
+		return false
+	}
+	return now.After(expires)
+}
+
+func resourceMetalAPIKeyRotationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	now := time.Now()
+	if apiKeyRotationDue(d, now) {
+		for _, k := range []string{"token", "created", "previous_id", "previous_token", "previous_expires_at"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if d.Get("previous_id").(string) != "" && apiKeyGraceExpired(d.Get("previous_expires_at").(string), now) {
+		for _, k := range []string{"previous_id", "previous_token", "previous_expires_at"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteMetalAPIKey(client *packngo.Client, id string) error {
+	resp, err := client.APIKeys.Delete(id)
+	if ignoreResponseErrors(httpForbidden, httpNotFound)(resp, err) != nil {
 		return friendlyError(err)
 	}
+	return nil
+}
 
-	d.SetId(apiKey.ID)
+func resourceMetalAPIKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).metal
+
+	now := time.Now()
+	// the planned previous key attributes are unknown, use the prior state
+	p, _ := d.GetChange("previous_id")
+	previousID := p.(string)
+	e, _ := d.GetChange("previous_expires_at")
+	previousExpiresAt := e.(string)
+	if apiKeyRotationDue(d, now) {
+		// only the last rotated key is kept, an older one is superseded
+		if previousID != "" {
+			if err := deleteMetalAPIKey(client, previousID); err != nil {
+				return err
+			}
+		}
+
+		apiKey, err := createMetalAPIKey(d, client)
+		if err != nil {
+			return err
+		}
+		grace, err := time.ParseDuration(d.Get("rotation.0.grace_period").(string))
+		if err != nil {
+			return fmt.Errorf("invalid rotation grace_period: %s", err)
+		}
+		o, _ := d.GetChange("token")
+		if err := setMap(d, map[string]interface{}{
+			"previous_id":         d.Id(),
+			"previous_token":      o.(string),
+			"previous_expires_at": now.Add(grace).UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] API key (%s) rotated to (%s)", d.Id(), apiKey.ID)
+		d.SetId(apiKey.ID)
+	} else if previousID != "" && apiKeyGraceExpired(previousExpiresAt, now) {
+		if err := deleteMetalAPIKey(client, previousID); err != nil {
+			return err
+		}
+		if err := setMap(d, map[string]interface{}{
+			"previous_id":         "",
+			"previous_token":      "",
+			"previous_expires_at": "",
+		}); err != nil {
+			return err
+		}
+	}
 
 	return resourceMetalAPIKeyRead(d, meta)
 }
@@ -117,6 +293,7 @@ func resourceMetalAPIKeyRead(d *schema.ResourceData, meta interface{}) error {
 		"description": apiKey.Description,
 		"read_only":   apiKey.ReadOnly,
 		"token":       apiKey.Token,
+		"created":     apiKey.Created,
 	}
 
 	// this is kind of unnecessary as the project ID most likely already set,
@@ -136,9 +313,13 @@ func resourceMetalAPIKeyDelete(d *schema.ResourceData, meta interface{}) error {
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
 
-	resp, err := client.APIKeys.Delete(d.Id())
-	if ignoreResponseErrors(httpForbidden, httpNotFound)(resp, err) != nil {
-		return friendlyError(err)
+	if previousID := d.Get("previous_id").(string); previousID != "" {
+		if err := deleteMetalAPIKey(client, previousID); err != nil {
+			return err
+		}
+	}
+	if err := deleteMetalAPIKey(client, d.Id()); err != nil {
+		return err
 	}
 
 	d.SetId("")
@@ -43,6 +43,57 @@ resource "equinix_metal_project_api_key" "test" {
 }`)
 }
 
+func TestAccMetalProjectAPIKey_rotation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalProjectAPIKeyCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalProjectAPIKeyConfig_rotation("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"equinix_metal_project_api_key.test", "token"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_project_api_key.test", "previous_id", ""),
+				),
+			},
+			{
+				Config: testAccMetalProjectAPIKeyConfig_rotation("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"equinix_metal_project_api_key.test", "previous_id"),
+					resource.TestCheckResourceAttrSet(
+						"equinix_metal_project_api_key.test", "previous_token"),
+					resource.TestCheckResourceAttrSet(
+						"equinix_metal_project_api_key.test", "previous_expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetalProjectAPIKeyConfig_rotation(keeper string) string {
+	return fmt.Sprintf(`
+resource "equinix_metal_project" "test" {
+    name = "tfacc-project-key-rotation-test"
+}
+
+resource "equinix_metal_project_api_key" "test" {
+    project_id  = equinix_metal_project.test.id
+    description = "tfacc-project-key-rotation"
+    read_only   = true
+
+    rotation {
+        keepers = {
+            generation = "%s"
+        }
+        grace_period = "1h"
+    }
+}`, keeper)
+}
+
 func testAccMetalProjectAPIKeyCheckDestroyed(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Config).metal
 	for _, rs := range s.RootModule().Resources {
@@ -0,0 +1,56 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalAPIKey_rotationDue(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	keyData := func(created string, attrs map[string]string) *terraform.InstanceState {
+		state := &terraform.InstanceState{
+			ID: "key-id",
+			Attributes: map[string]string{
+				"id":          "key-id",
+				"description": "test",
+				"read_only":   "false",
+				"created":     created,
+			},
+		}
+		for k, v := range attrs {
+			state.Attributes[k] = v
+		}
+		return state
+	}
+	rotation := map[string]string{
+		"rotation.#":              "1",
+		"rotation.0.interval":     "2160h",
+		"rotation.0.grace_period": "24h",
+	}
+	r := resourceMetalUserAPIKey()
+	// when
+	fresh := apiKeyRotationDue(r.Data(keyData(now.Add(-24*time.Hour).Format(time.RFC3339), rotation)), now)
+	old := apiKeyRotationDue(r.Data(keyData(now.Add(-91*24*time.Hour).Format(time.RFC3339), rotation)), now)
+	noRotation := apiKeyRotationDue(r.Data(keyData(now.Add(-91*24*time.Hour).Format(time.RFC3339), nil)), now)
+	// then
+	assert.False(t, fresh, "key younger than the interval is not rotated")
+	assert.True(t, old, "key older than the interval is rotated")
+	assert.False(t, noRotation, "key without rotation block is not rotated")
+}
+
+func TestMetalAPIKey_graceExpired(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	// when
+	expired := apiKeyGraceExpired(now.Add(-time.Minute).Format(time.RFC3339), now)
+	pending := apiKeyGraceExpired(now.Add(time.Hour).Format(time.RFC3339), now)
+	unset := apiKeyGraceExpired("", now)
+	// then
+	assert.True(t, expired)
+	assert.False(t, pending)
+	assert.False(t, unset)
+}
@@ -0,0 +1,320 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+func resourceMetalServiceTokenRedemption() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMetalServiceTokenRedemptionCreate,
+		ReadContext:   resourceMetalServiceTokenRedemptionRead,
+		DeleteContext: resourceFabricConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMetalServiceTokenRedemptionImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Metal connection the service token belongs to",
+			},
+			"service_token_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Metal connection service token to redeem",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Fabric connection",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"bandwidth": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Bandwidth of the Fabric connection in Mbps. It can not exceed the max_allowed_speed of the service token",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"fabric_port_uuid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "UUID of the Fabric port on the other side of the connection",
+				ValidateFunc: validation.IsUUID,
+			},
+			"vlan_tag": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Dot1q VLAN tag of the Fabric port side of the connection",
+				ValidateFunc: validation.IntBetween(2, 4094),
+			},
+			"notification_emails": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Email addresses notified about the Fabric connection",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"purchase_order_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Purchase order number of the Fabric connection",
+			},
+			"service_token_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the redeemed service token, a_side or z_side",
+			},
+			"virtual_circuit_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Metal virtual circuit associated with the service token",
+			},
+			"virtual_circuit_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the Metal virtual circuit",
+			},
+			"fabric_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the Fabric connection",
+			},
+		},
+	}
+}
+
+// resourceMetalServiceTokenRedemptionImport imports the Fabric connection
+// with an ID of the form {fabric_connection_id}:{metal_connection_id}. The
+// redeemed service token and the Fabric port side arguments are taken from
+// the Fabric connection.
+func resourceMetalServiceTokenRedemptionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected {fabric_connection_id}:{metal_connection_id}", d.Id())
+	}
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*Config).FabricAuthToken)
+	conn, _, err := meta.(*Config).fabricClient.ConnectionsApi.GetConnectionByUuid(ctx, parts[0], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSide, portSide := conn.ASide, conn.ZSide
+	if tokenSide == nil || tokenSide.ServiceToken == nil {
+		tokenSide, portSide = conn.ZSide, conn.ASide
+	}
+	if tokenSide == nil || tokenSide.ServiceToken == nil {
+		return nil, fmt.Errorf("connection %s was not created with a service token", parts[0])
+	}
+
+	emails := []string{}
+	for _, n := range conn.Notifications {
+		emails = append(emails, n.Emails...)
+	}
+	attrs := map[string]interface{}{
+		"connection_id":       parts[1],
+		"service_token_id":    tokenSide.ServiceToken.Uuid,
+		"notification_emails": emails,
+	}
+	if conn.Order != nil {
+		attrs["purchase_order_number"] = conn.Order.PurchaseOrderNumber
+	}
+	if portSide != nil && portSide.AccessPoint != nil {
+		if portSide.AccessPoint.Port != nil {
+			attrs["fabric_port_uuid"] = portSide.AccessPoint.Port.Uuid
+		}
+		if portSide.AccessPoint.LinkProtocol != nil {
+			attrs["vlan_tag"] = portSide.AccessPoint.LinkProtocol.VlanTag
+		}
+	}
+	d.SetId(parts[0])
+	if err := setMap(d, attrs); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// getServiceTokenVirtualCircuit looks up the service token in the Metal
+// connection and returns it together with the virtual circuit of the port the
+// token was issued for.
+func getServiceTokenVirtualCircuit(client *packngo.Client, connID, tokenID string) (*packngo.FabricServiceToken, *packngo.VirtualCircuit, error) {
+	conn, _, err := client.Connections.Get(connID, &packngo.GetOptions{Includes: []string{"service_tokens", "ports.virtual_circuits"}})
+	if err != nil {
+		return nil, nil, friendlyError(err)
+	}
+
+	var token *packngo.FabricServiceToken
+	for i := range conn.Tokens {
+		if conn.Tokens[i].ID == tokenID {
+			token = &conn.Tokens[i]
+			break
+		}
+	}
+	if token == nil {
+		return nil, nil, fmt.Errorf("service token %s not found in connection %s", tokenID, connID)
+	}
+
+	for _, p := range conn.Ports {
+		if p.Role == token.Role && len(p.VirtualCircuits) > 0 {
+			return token, &p.VirtualCircuits[0], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no virtual circuit found for %s port of connection %s", token.Role, connID)
+}
+
+func resourceMetalServiceTokenRedemptionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).fabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*Config).FabricAuthToken)
+
+	token, vc, err := getServiceTokenVirtualCircuit(meta.(*Config).metal, d.Get("connection_id").(string), d.Get("service_token_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apType := v4.COLO_AccessPointType
+	lpType := v4.DOT1_Q_LinkProtocolType
+	portSide := v4.ConnectionSide{
+		AccessPoint: &v4.AccessPoint{
+			Type_: &apType,
+			Port:  &v4.SimplifiedPort{Uuid: d.Get("fabric_port_uuid").(string)},
+			LinkProtocol: &v4.SimplifiedLinkProtocol{
+				Type_:   &lpType,
+				VlanTag: int32(d.Get("vlan_tag").(int)),
+			},
+		},
+	}
+	tokenSide := v4.ConnectionSide{ServiceToken: &v4.ServiceToken{Uuid: token.ID}}
+
+	// the token type tells which side of the connection Metal is on
+	aSide, zSide := portSide, tokenSide
+	if token.ServiceTokenType == packngo.FabricServiceTokenASide {
+		aSide, zSide = tokenSide, portSide
+	}
+
+	conType := v4.EVPL_VC_ConnectionType
+	priority := v4.PRIMARY_ConnectionPriority
+	createRequest := v4.ConnectionPostRequest{
+		Name:  d.Get("name").(string),
+		Type_: &conType,
+		Order: &v4.Order{PurchaseOrderNumber: d.Get("purchase_order_number").(string)},
+		Notifications: []v4.SimplifiedNotification{{
+			Type_:  "ALL",
+			Emails: expandListToStringList(d.Get("notification_emails").([]interface{})),
+		}},
+		Bandwidth:  int32(d.Get("bandwidth").(int)),
+		Redundancy: &v4.ConnectionRedundancy{Priority: &priority},
+		ASide:      &aSide,
+		ZSide:      &zSide,
+	}
+
+	conn, _, err := client.ConnectionsApi.CreateConnection(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(conn.Uuid)
+	d.Set("virtual_circuit_id", vc.ID)
+
+	if err = waitUntilConnectionIsCreated(d.Id(), meta, ctx); err != nil {
+		return diag.Errorf("error waiting for connection (%s) to be created: %s", d.Id(), err)
+	}
+	if err = waitUntilConnectionIsActive(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for connection (%s) to become active: %s", d.Id(), err)
+	}
+
+	vcWaiter := getVCStateWaiter(
+		meta.(*Config).metal,
+		vc.ID,
+		d.Timeout(schema.TimeoutCreate),
+		[]string{string(packngo.VCStatusPending), string(packngo.VCStatusWaiting), string(packngo.VCStatusActivating)},
+		[]string{string(packngo.VCStatusActive)},
+	)
+	if _, err = vcWaiter.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for virtual circuit (%s) to become active: %s", vc.ID, err)
+	}
+
+	return resourceMetalServiceTokenRedemptionRead(ctx, d, meta)
+}
+
+func resourceMetalServiceTokenRedemptionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	client := meta.(*Config).fabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*Config).FabricAuthToken)
+
+	conn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, d.Id(), nil)
+	if err != nil {
+		log.Printf("[WARN] Connection %s not found , error %s", d.Id(), err)
+		if !strings.Contains(err.Error(), "500") {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+
+	token, vc, err := getServiceTokenVirtualCircuit(meta.(*Config).metal, d.Get("connection_id").(string), d.Get("service_token_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fabricState := ""
+	if conn.State != nil {
+		fabricState = string(*conn.State)
+	}
+
+	return diag.FromErr(setMap(d, map[string]interface{}{
+		"name":                   conn.Name,
+		"bandwidth":              conn.Bandwidth,
+		"service_token_type":     string(token.ServiceTokenType),
+		"virtual_circuit_id":     vc.ID,
+		"virtual_circuit_status": string(vc.Status),
+		"fabric_state":           fabricState,
+	}))
+}
+
+func waitUntilConnectionIsActive(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) error {
+	log.Printf("Waiting for connection to be active, uuid %s", uuid)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{
+			string(v4.PROVISIONING_ConnectionState),
+			string(v4.PENDING_ConnectionState),
+			string(v4.PROVISIONED_ConnectionState),
+		},
+		Target: []string{
+			string(v4.ACTIVE_ConnectionState),
+		},
+		Refresh: func() (interface{}, string, error) {
+			client := meta.(*Config).fabricClient
+			dbConn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, uuid, nil)
+			if err != nil {
+				return "", "", err
+			}
+			return dbConn, string(*dbConn.State), nil
+		},
+		Timeout:    timeout,
+		Delay:      30 * time.Second,
+		MinTimeout: 30 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
@@ -0,0 +1,69 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetalServiceTokenRedemption_zSide(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalServiceTokenRedemptionConfig_zSide(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_service_token_redemption.test", "service_token_type", "z_side"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_service_token_redemption.test", "fabric_state", "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_service_token_redemption.test", "virtual_circuit_status", "active"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_connection.test", "ports.0.virtual_circuit_ids.0",
+						"equinix_metal_service_token_redemption.test", "virtual_circuit_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetalServiceTokenRedemptionConfig_zSide(r int) string {
+	return fmt.Sprintf(`
+		resource "equinix_metal_project" "test" {
+			name = "tfacc-conn-project-%d"
+		}
+
+		resource "equinix_metal_vlan" "test" {
+			project_id = equinix_metal_project.test.id
+			metro      = "sv"
+		}
+
+		resource "equinix_metal_connection" "test" {
+			name               = "tfacc-conn-%d"
+			project_id         = equinix_metal_project.test.id
+			type               = "shared"
+			redundancy         = "primary"
+			metro              = "sv"
+			speed              = "50Mbps"
+			service_token_type = "z_side"
+			vlans              = [equinix_metal_vlan.test.vxlan]
+		}
+
+		resource "equinix_metal_service_token_redemption" "test" {
+			connection_id       = equinix_metal_connection.test.id
+			service_token_id    = equinix_metal_connection.test.service_tokens.0.id
+			name                = "tfacc-redeem-%d"
+			bandwidth           = 50
+			fabric_port_uuid    = "eb92632a-3747-7478-b5e0-306a5c00aecd"
+			vlan_tag            = 2399
+			notification_emails = ["test@equinix.com"]
+		}`,
+		r, r, r)
+}
@@ -12,9 +12,11 @@ func resourceMetalUserAPIKey() *schema.Resource {
 		Description: "UUID of user owning this key",
 	}
 	return &schema.Resource{
-		Create: resourceMetalAPIKeyCreate,
-		Read:   resourceMetalAPIKeyRead,
-		Delete: resourceMetalAPIKeyDelete,
+		Create:        resourceMetalAPIKeyCreate,
+		Read:          resourceMetalAPIKeyRead,
+		Update:        resourceMetalAPIKeyUpdate,
+		Delete:        resourceMetalAPIKeyDelete,
+		CustomizeDiff: resourceMetalAPIKeyRotationDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/packethost/packngo"
 )
 
@@ -23,6 +25,11 @@ func resourceMetalVirtualCircuit() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"connection_id": {
@@ -90,7 +97,6 @@ func resourceMetalVirtualCircuit() *schema.Resource {
 				Optional:     true,
 				RequiredWith: []string{"vrf_id"},
 				Description:  "The BGP ASN of the peer. The same ASN may be the used across several VCs, but it cannot be the same as the local_asn of the VRF.",
-				ForceNew:     true,
 			},
 			"subnet": {
 				Type:         schema.TypeString,
@@ -113,10 +119,34 @@ func resourceMetalVirtualCircuit() *schema.Resource {
 				Description:  "The Customer IP address which the CSR switch will peer with. Will default to the other usable IP in the subnet.",
 			},
 			"md5": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The password that can be set for the VRF BGP peer",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"vrf_id"},
+				Description:  "The password that can be set for the VRF BGP peer",
+			},
+			"subnet_ipv6": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"vrf_id"},
+				ValidateFunc: validation.IsCIDR,
+				Description:  "An IPv6 subnet from one of the IP blocks associated with the VRF that we will help create an IP reservation for. Can only be either a /126 or /127, used for IPv6 BGP peering",
+			},
+			"metal_ipv6": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"subnet_ipv6"},
+				ValidateFunc: validation.IsIPv6Address,
+				Description:  "The Metal IPv6 address for the SVI (Switch Virtual Interface) of the VirtualCircuit. Will default to the first usable IP in the subnet_ipv6.",
+			},
+			"customer_ipv6": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"subnet_ipv6"},
+				ValidateFunc: validation.IsIPv6Address,
+				Description:  "The Customer IPv6 address which the CSR switch will peer with. Will default to the other usable IP in the subnet_ipv6.",
 			},
 
 			"vnid": {
@@ -141,7 +171,7 @@ func resourceMetalVirtualCircuit() *schema.Resource {
 func resourceMetalVirtualCircuitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
-	vncr := packngo.VCCreateRequest{
+	vncr := metalVCCreateRequest{VCCreateRequest: packngo.VCCreateRequest{
 		VirtualNetworkID: d.Get("vlan_id").(string),
 		Name:             d.Get("name").(string),
 		Description:      d.Get("description").(string),
@@ -152,6 +182,10 @@ func resourceMetalVirtualCircuitCreate(ctx context.Context, d *schema.ResourceDa
 		MetalIP:          d.Get("metal_ip").(string),
 		CustomerIP:       d.Get("customer_ip").(string),
 		MD5:              d.Get("md5").(string),
+	},
+		SubnetIPv6:   d.Get("subnet_ipv6").(string),
+		MetalIPv6:    d.Get("metal_ipv6").(string),
+		CustomerIPv6: d.Get("customer_ipv6").(string),
 	}
 
 	connId := d.Get("connection_id").(string)
@@ -174,7 +208,7 @@ func resourceMetalVirtualCircuitCreate(ctx context.Context, d *schema.ResourceDa
 		return fmt.Errorf("Connection request with name %s and ID %s wasn't approved yet", conn.Name, conn.ID)
 	}
 
-	vc, _, err := client.VirtualCircuits.Create(projectId, connId, portId, &vncr, nil)
+	vc, _, err := createMetalVirtualCircuit(client, projectId, connId, portId, &vncr)
 	if err != nil {
 		log.Printf("[DEBUG] Error creating virtual circuit: %s", err)
 		return err
@@ -203,7 +237,8 @@ func resourceMetalVirtualCircuitRead(ctx context.Context, d *schema.ResourceData
 	client := meta.(*Config).metal
 	vcId := d.Id()
 
-	vc, _, err := client.VirtualCircuits.Get(
+	vc, _, err := getMetalVirtualCircuit(
+		client,
 		vcId,
 		&packngo.GetOptions{Includes: []string{"project", "virtual_network", "vrf"}},
 	)
@@ -240,19 +275,22 @@ func resourceMetalVirtualCircuitRead(ctx context.Context, d *schema.ResourceData
 			}
 			return nil
 		},
-		"status":      vc.Status,
-		"nni_vlan":    vc.NniVLAN,
-		"vnid":        vc.VNID,
-		"nni_vnid":    vc.NniVNID,
-		"name":        vc.Name,
-		"speed":       strconv.Itoa(vc.Speed),
-		"description": vc.Description,
-		"tags":        vc.Tags,
-		"peer_asn":    vc.PeerASN,
-		"subnet":      vc.Subnet,
-		"metal_ip":    vc.MetalIP,
-		"customer_ip": vc.CustomerIP,
-		"md5":         vc.MD5,
+		"status":        vc.Status,
+		"nni_vlan":      vc.NniVLAN,
+		"vnid":          vc.VNID,
+		"nni_vnid":      vc.NniVNID,
+		"name":          vc.Name,
+		"speed":         strconv.Itoa(vc.Speed),
+		"description":   vc.Description,
+		"tags":          vc.Tags,
+		"peer_asn":      vc.PeerASN,
+		"subnet":        vc.Subnet,
+		"metal_ip":      vc.MetalIP,
+		"customer_ip":   vc.CustomerIP,
+		"md5":           vc.MD5,
+		"subnet_ipv6":   vc.SubnetIPv6,
+		"metal_ipv6":    vc.MetalIPv6,
+		"customer_ipv6": vc.CustomerIPv6,
 		"connection_id": func(d *schema.ResourceData, k string) error {
 			if connectionID != "" {
 				return d.Set(k, connectionID)
@@ -283,7 +321,7 @@ func resourceMetalVirtualCircuitUpdate(ctx context.Context, d *schema.ResourceDa
 	meta.(*Config).addModuleToMetalUserAgent(d)
 	client := meta.(*Config).metal
 
-	ur := packngo.VCUpdateRequest{}
+	ur := metalVCUpdateRequest{}
 	if d.HasChange("vlan_id") {
 		vnid := d.Get("vlan_id").(string)
 		ur.VirtualNetworkID = &vnid
@@ -319,10 +357,56 @@ func resourceMetalVirtualCircuitUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
-	if !reflect.DeepEqual(ur, packngo.VCUpdateRequest{}) {
-		if _, _, err := client.VirtualCircuits.Update(d.Id(), &ur, nil); err != nil {
+	// VRF BGP peering attributes
+	sPtr := func(s string) *string { return &s }
+	if d.HasChange("peer_asn") {
+		peerASN := d.Get("peer_asn").(int)
+		ur.PeerASN = &peerASN
+	}
+	if d.HasChange("subnet") {
+		ur.Subnet = sPtr(d.Get("subnet").(string))
+	}
+	if d.HasChange("metal_ip") {
+		ur.MetalIP = sPtr(d.Get("metal_ip").(string))
+	}
+	if d.HasChange("customer_ip") {
+		ur.CustomerIP = sPtr(d.Get("customer_ip").(string))
+	}
+	if d.HasChange("md5") {
+		// an empty string removes the password from the BGP peer
+		ur.MD5 = sPtr(d.Get("md5").(string))
+	}
+	if d.HasChange("subnet_ipv6") {
+		ur.SubnetIPv6 = sPtr(d.Get("subnet_ipv6").(string))
+	}
+	if d.HasChange("metal_ipv6") {
+		ur.MetalIPv6 = sPtr(d.Get("metal_ipv6").(string))
+	}
+	if d.HasChange("customer_ipv6") {
+		ur.CustomerIPv6 = sPtr(d.Get("customer_ipv6").(string))
+	}
+
+	if !reflect.DeepEqual(ur, metalVCUpdateRequest{}) {
+		if _, _, err := updateMetalVirtualCircuit(client, d.Id(), &ur); err != nil {
 			return friendlyError(err)
 		}
+
+		// a VC waiting for the customer side stays waiting after the update,
+		// any other VC has to become active again
+		target := []string{string(packngo.VCStatusActive)}
+		if d.Get("status").(string) == string(packngo.VCStatusWaiting) {
+			target = append(target, string(packngo.VCStatusWaiting))
+		}
+		updateWaiter := getVCStateWaiter(
+			client,
+			d.Id(),
+			d.Timeout(schema.TimeoutUpdate)-30*time.Second,
+			[]string{string(packngo.VCStatusPending), string(packngo.VCStatusActivating)},
+			target,
+		)
+		if _, err := updateWaiter.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("Error waiting for virtual circuit %s to be updated: %s", d.Id(), err.Error())
+		}
 	}
 	return resourceMetalVirtualCircuitRead(ctx, d, meta)
 }
@@ -351,3 +435,56 @@ func resourceMetalVirtualCircuitDelete(ctx context.Context, d *schema.ResourceDa
 	d.SetId("")
 	return nil
 }
+
+// metalVirtualCircuit extends the packngo VirtualCircuit with the VRF IPv6
+// peering attributes which are not modeled by packngo.
+type metalVirtualCircuit struct {
+	packngo.VirtualCircuit
+	SubnetIPv6   string `json:"subnet_ipv6,omitempty"`
+	MetalIPv6    string `json:"metal_ipv6,omitempty"`
+	CustomerIPv6 string `json:"customer_ipv6,omitempty"`
+}
+
+type metalVCCreateRequest struct {
+	packngo.VCCreateRequest
+	SubnetIPv6   string `json:"subnet_ipv6,omitempty"`
+	MetalIPv6    string `json:"metal_ipv6,omitempty"`
+	CustomerIPv6 string `json:"customer_ipv6,omitempty"`
+}
+
+// metalVCUpdateRequest extends the packngo VCUpdateRequest with the VRF BGP
+// peering attributes accepted by the virtual circuit update endpoint.
+type metalVCUpdateRequest struct {
+	packngo.VCUpdateRequest
+	PeerASN      *int    `json:"peer_asn,omitempty"`
+	Subnet       *string `json:"subnet,omitempty"`
+	MetalIP      *string `json:"metal_ip,omitempty"`
+	CustomerIP   *string `json:"customer_ip,omitempty"`
+	MD5          *string `json:"md5,omitempty"`
+	SubnetIPv6   *string `json:"subnet_ipv6,omitempty"`
+	MetalIPv6    *string `json:"metal_ipv6,omitempty"`
+	CustomerIPv6 *string `json:"customer_ipv6,omitempty"`
+}
+
+func doMetalVirtualCircuitRequest(client *packngo.Client, method, apiPathQuery string, req interface{}) (*metalVirtualCircuit, *packngo.Response, error) {
+	vc := new(metalVirtualCircuit)
+	resp, err := client.DoRequest(method, apiPathQuery, req, vc)
+	if err != nil {
+		return nil, resp, err
+	}
+	return vc, resp, err
+}
+
+func createMetalVirtualCircuit(client *packngo.Client, projectID, connID, portID string, req *metalVCCreateRequest) (*metalVirtualCircuit, *packngo.Response, error) {
+	apiPath := path.Join("/projects", projectID, "connections", connID, "ports", portID, "virtual-circuits")
+	return doMetalVirtualCircuitRequest(client, "POST", apiPath, req)
+}
+
+func getMetalVirtualCircuit(client *packngo.Client, id string, opts *packngo.GetOptions) (*metalVirtualCircuit, *packngo.Response, error) {
+	apiPathQuery := opts.WithQuery(path.Join("/virtual-circuits", id))
+	return doMetalVirtualCircuitRequest(client, "GET", apiPathQuery, nil)
+}
+
+func updateMetalVirtualCircuit(client *packngo.Client, id string, req *metalVCUpdateRequest) (*metalVirtualCircuit, *packngo.Response, error) {
+	return doMetalVirtualCircuitRequest(client, "PUT", path.Join("/virtual-circuits", id), req)
+}
@@ -2,9 +2,13 @@ package equinix
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/packethost/packngo"
-	"log"
 )
 
 func resourceMetalVRF() *schema.Resource {
@@ -147,3 +151,53 @@ func resourceMetalVRFDelete(ctx context.Context, d *schema.ResourceData, meta in
 
 	return friendlyError(err)
 }
+
+// vrfIPRangesContain reports whether the given CIDR or IP address falls
+// entirely within one of the VRF IP ranges.
+func vrfIPRangesContain(ipRanges []string, prefix string) (bool, error) {
+	if !strings.Contains(prefix, "/") {
+		ip := net.ParseIP(prefix)
+		if ip == nil {
+			return false, fmt.Errorf("%q is not a valid IP address", prefix)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			bits = 32
+		}
+		prefix = fmt.Sprintf("%s/%d", prefix, bits)
+	}
+	ip, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false, err
+	}
+	ones, _ := ipNet.Mask.Size()
+
+	for _, r := range ipRanges {
+		_, rangeNet, err := net.ParseCIDR(r)
+		if err != nil {
+			continue
+		}
+		rangeOnes, _ := rangeNet.Mask.Size()
+		if rangeNet.Contains(ip) && rangeOnes <= ones && len(rangeNet.IP) == len(ipNet.IP) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateInVRFIPRanges fetches the VRF and returns an error when the prefix
+// of attribute k does not fall within any of the VRF ip_ranges.
+func validateInVRFIPRanges(client *packngo.Client, vrfID, k, prefix string) error {
+	vrf, _, err := client.VRFs.Get(vrfID, nil)
+	if err != nil {
+		return friendlyError(err)
+	}
+	ok, err := vrfIPRangesContain(vrf.IPRanges, prefix)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", k, err)
+	}
+	if !ok {
+		return fmt.Errorf("%s %s is not within the ip_ranges of VRF %s: %v", k, prefix, vrfID, vrf.IPRanges)
+	}
+	return nil
+}
@@ -267,6 +267,29 @@ func TestAccMetalVRFConfig_withConnection(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config: testAccMetalVRFConfig_withVCUpdate(rInt, nniVlan),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"peer_asn", "65531"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"subnet", "192.168.100.18/31"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"metal_ip", "192.168.100.18"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"customer_ip", "192.168.100.19"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"md5", "tfacc-md5-secret"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_virtual_circuit.test",
+						"status", "active"),
+				),
+			},
 			{
 				Config: testAccMetalVRFConfig_withVCGateway(rInt, nniVlan),
 				Check: resource.ComposeTestCheckFunc(
@@ -430,6 +453,32 @@ func testAccMetalVRFConfig_withVC(r, nniVlan int) string {
 	`, testConnection, r, r, nniVlan)
 }
 
+func testAccMetalVRFConfig_withVCUpdate(r, nniVlan int) string {
+	// Dedicated connection in DA metro
+	testConnection := os.Getenv(metalDedicatedConnIDEnvVar)
+	return testAccMetalVRFConfig_withIPRanges(r) + fmt.Sprintf(`
+
+	data "equinix_metal_connection" "test" {
+		connection_id = "%s"
+	}
+
+	resource "equinix_metal_virtual_circuit" "test" {
+		name = "tfacc-vc-%d"
+		description = "tfacc-vc-%d"
+		connection_id = data.equinix_metal_connection.test.id
+		project_id = equinix_metal_project.test.id
+		port_id = data.equinix_metal_connection.test.ports[0].id
+		nni_vlan = %d
+		vrf_id = equinix_metal_vrf.test.id
+		peer_asn = 65531
+		subnet = "192.168.100.18/31"
+		metal_ip = "192.168.100.18"
+		customer_ip = "192.168.100.19"
+		md5 = "tfacc-md5-secret"
+	}
+	`, testConnection, r, r, nniVlan)
+}
+
 func testAccMetalVRFConfig_withVCGateway(r, nniVlan int) string {
 	// Dedicated connection in DA metro
 	testConnection := os.Getenv(metalDedicatedConnIDEnvVar)
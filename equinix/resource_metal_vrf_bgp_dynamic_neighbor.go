@@ -0,0 +1,196 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+func resourceMetalVRFBGPDynamicNeighbor() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout:   diagnosticsWrapper(resourceMetalVRFBGPDynamicNeighborRead),
+		CreateWithoutTimeout: diagnosticsWrapper(resourceMetalVRFBGPDynamicNeighborCreate),
+		DeleteWithoutTimeout: diagnosticsWrapper(resourceMetalVRFBGPDynamicNeighborDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceMetalVRFBGPDynamicNeighborDiff,
+
+		Schema: map[string]*schema.Schema{
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the VRF Metal Gateway where the BGP dynamic neighbor range is configured",
+			},
+			"range": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Network range in CIDR notation of the BGP dynamic neighbors. It must fall within the ip_ranges of the gateway VRF",
+				ValidateFunc: validation.IsCIDR,
+			},
+			"asn": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ASN of the BGP dynamic neighbors, up to 2147483647",
+				ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Tags attached to the BGP dynamic neighbor",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the BGP dynamic neighbor",
+			},
+		},
+	}
+}
+
+func resourceMetalVRFBGPDynamicNeighborCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	gatewayID := d.Get("gateway_id").(string)
+	bgpRange := d.Get("range").(string)
+
+	createRequest := metalv1.NewBgpDynamicNeighborCreateInput(bgpRange, int32(d.Get("asn").(int)))
+	createRequest.Tags = convertStringArr(d.Get("tags").([]interface{}))
+
+	neighbor, resp, err := client.VRFsApi.CreateBgpDynamicNeighbor(ctx, gatewayID).
+		BgpDynamicNeighborCreateInput(*createRequest).Execute()
+	if err != nil {
+		return friendlyErrorForMetalGo(err, resp)
+	}
+
+	d.SetId(neighbor.GetId())
+
+	createWaiter := getMetalVRFBGPDynamicNeighborStateWaiter(
+		client,
+		d.Id(),
+		d.Timeout(schema.TimeoutCreate),
+		[]string{string(metalv1.BGPDYNAMICNEIGHBORSTATE_PENDING)},
+		[]string{string(metalv1.BGPDYNAMICNEIGHBORSTATE_ACTIVE), string(metalv1.BGPDYNAMICNEIGHBORSTATE_READY)},
+	)
+	if _, err = createWaiter.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for BGP dynamic neighbor %s to be ready: %s", d.Id(), err)
+	}
+
+	return resourceMetalVRFBGPDynamicNeighborRead(ctx, d, meta)
+}
+
+// resourceMetalVRFBGPDynamicNeighborDiff checks that the neighbor range is
+// part of the VRF ip_ranges, so that a rejected range fails the plan instead of
+// the apply. The check is skipped until the gateway and range are known.
+func resourceMetalVRFBGPDynamicNeighborDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("gateway_id") || !d.NewValueKnown("range") {
+		return nil
+	}
+	gatewayID := d.Get("gateway_id").(string)
+	gw, _, err := meta.(*Config).metal.MetalGateways.Get(gatewayID, &packngo.GetOptions{Includes: []string{"vrf"}})
+	if err != nil {
+		return friendlyError(err)
+	}
+	if gw.VRF == nil {
+		return fmt.Errorf("metal gateway %s is not associated with a VRF", gatewayID)
+	}
+	return validateInVRFIPRanges(meta.(*Config).metal, gw.VRF.ID, "range", d.Get("range").(string))
+}
+
+func resourceMetalVRFBGPDynamicNeighborRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	neighbor, resp, err := client.VRFsApi.BgpDynamicNeighborsIdGet(ctx, d.Id()).
+		Include([]string{"metal_gateway"}).Execute()
+	if err != nil {
+		err = friendlyErrorForMetalGo(err, resp)
+		if isNotFound(err) || isForbidden(err) {
+			log.Printf("[WARN] BGP dynamic neighbor (%s) not accessible, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+		return err
+	}
+
+	return setMap(d, map[string]interface{}{
+		"gateway_id": neighbor.MetalGateway.GetId(),
+		"range":      neighbor.GetBgpNeighborRange(),
+		"asn":        neighbor.GetBgpNeighborAsn(),
+		"tags":       neighbor.GetTags(),
+		"state":      string(neighbor.GetState()),
+	})
+}
+
+func resourceMetalVRFBGPDynamicNeighborDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	_, resp, err := client.VRFsApi.DeleteBgpDynamicNeighborById(ctx, d.Id()).Execute()
+	if err != nil {
+		err = friendlyErrorForMetalGo(err, resp)
+		if isNotFound(err) || isForbidden(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	deleteWaiter := getMetalVRFBGPDynamicNeighborStateWaiter(
+		client,
+		d.Id(),
+		d.Timeout(schema.TimeoutDelete),
+		[]string{string(metalv1.BGPDYNAMICNEIGHBORSTATE_DELETING), string(metalv1.BGPDYNAMICNEIGHBORSTATE_ACTIVE), string(metalv1.BGPDYNAMICNEIGHBORSTATE_READY)},
+		[]string{},
+	)
+	if _, err = deleteWaiter.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error deleting BGP dynamic neighbor %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getMetalVRFBGPDynamicNeighborStateWaiter waits for the BGP dynamic neighbor
+// to reach one of the target states. An empty target waits for the neighbor
+// to be removed.
+func getMetalVRFBGPDynamicNeighborStateWaiter(client *metalv1.APIClient, id string, timeout time.Duration, pending, target []string) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			neighbor, resp, err := client.VRFsApi.BgpDynamicNeighborsIdGet(context.Background(), id).Execute()
+			if err != nil {
+				err = friendlyErrorForMetalGo(err, resp)
+				if isNotFound(err) {
+					return nil, "", nil
+				}
+				return nil, "", err
+			}
+			return neighbor, string(neighbor.GetState()), nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,58 @@
+package equinix
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetalVRFBGPDynamicNeighbor_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalVRFCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMetalVRFBGPDynamicNeighborConfig(rInt, "10.0.0.0/29"),
+				ExpectError: regexp.MustCompile("is not within the ip_ranges"),
+			},
+			{
+				Config: testAccMetalVRFBGPDynamicNeighborConfig(rInt, "192.168.100.8/29"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_vrf_bgp_dynamic_neighbor.test", "gateway_id",
+						"equinix_metal_gateway.test", "id"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_bgp_dynamic_neighbor.test", "range", "192.168.100.8/29"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_bgp_dynamic_neighbor.test", "asn", "56789"),
+					resource.TestCheckResourceAttrSet(
+						"equinix_metal_vrf_bgp_dynamic_neighbor.test", "state"),
+				),
+			},
+			{
+				ResourceName:      "equinix_metal_vrf_bgp_dynamic_neighbor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMetalVRFBGPDynamicNeighborConfig(r int, bgpRange string) string {
+	return testAccMetalVRFConfig_withGateway(r) + fmt.Sprintf(`
+
+resource "equinix_metal_vrf_bgp_dynamic_neighbor" "test" {
+	gateway_id = equinix_metal_gateway.test.id
+	range      = "%s"
+	asn        = 56789
+	tags       = ["tfacc"]
+}
+`, bgpRange)
+}
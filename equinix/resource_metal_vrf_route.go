@@ -0,0 +1,252 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	metalv1 "github.com/equinix-labs/metal-go/metal/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// default routes point outside of the VRF and are exempt from the ip_ranges check
+var vrfDefaultRoutePrefixes = []string{"0.0.0.0/0", "::/0"}
+
+func resourceMetalVRFRoute() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout:   diagnosticsWrapper(resourceMetalVRFRouteRead),
+		CreateWithoutTimeout: diagnosticsWrapper(resourceMetalVRFRouteCreate),
+		UpdateWithoutTimeout: diagnosticsWrapper(resourceMetalVRFRouteUpdate),
+		DeleteWithoutTimeout: diagnosticsWrapper(resourceMetalVRFRouteDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceMetalVRFRouteDiff,
+
+		Schema: map[string]*schema.Schema{
+			"vrf_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the VRF where the route is configured",
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The IPv4 or IPv6 prefix of the static route in CIDR notation. Unless it is a default route (0.0.0.0/0 or ::/0) it must fall within the ip_ranges of the VRF",
+				ValidateFunc: validation.IsCIDR,
+			},
+			"next_hop": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The next hop IPv4 or IPv6 address of the static route. It must fall within the ip_ranges of the VRF",
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Tags attached to the route",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the route, only static routes are supported",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the route",
+			},
+			"metal_gateway_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the Metal Gateway the route's next hop is reached through",
+			},
+			"virtual_network_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the VLAN the route's next hop is reached through",
+			},
+		},
+	}
+}
+
+// resourceMetalVRFRouteDiff checks at plan time that the route prefix and
+// next hop fall within the VRF ip_ranges. Routes of a VRF which is not known
+// yet are checked by the diff done on apply.
+func resourceMetalVRFRouteDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("prefix", "next_hop") {
+		return nil
+	}
+	if !d.NewValueKnown("vrf_id") || !d.NewValueKnown("prefix") || !d.NewValueKnown("next_hop") {
+		return nil
+	}
+	client := meta.(*Config).metal
+	vrfID := d.Get("vrf_id").(string)
+	prefix := d.Get("prefix").(string)
+
+	if !contains(vrfDefaultRoutePrefixes, prefix) {
+		if err := validateInVRFIPRanges(client, vrfID, "prefix", prefix); err != nil {
+			return err
+		}
+	}
+	return validateInVRFIPRanges(client, vrfID, "next_hop", d.Get("next_hop").(string))
+}
+
+func resourceMetalVRFRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	createRequest := metalv1.NewVrfRouteCreateInput(d.Get("prefix").(string), d.Get("next_hop").(string))
+	createRequest.Tags = convertStringArr(d.Get("tags").([]interface{}))
+
+	route, resp, err := client.VRFsApi.CreateVrfRoute(ctx, d.Get("vrf_id").(string)).
+		VrfRouteCreateInput(*createRequest).Execute()
+	if err != nil {
+		return friendlyErrorForMetalGo(err, resp)
+	}
+
+	d.SetId(route.GetId())
+
+	if err := waitForActiveMetalVRFRoute(ctx, client, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceMetalVRFRouteRead(ctx, d, meta)
+}
+
+func resourceMetalVRFRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalUserAgent(d)
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	updateRequest := metalv1.VrfRouteUpdateInput{}
+	if d.HasChange("prefix") {
+		updateRequest.SetPrefix(d.Get("prefix").(string))
+	}
+	if d.HasChange("next_hop") {
+		updateRequest.SetNextHop(d.Get("next_hop").(string))
+	}
+	if d.HasChange("tags") {
+		updateRequest.SetTags(convertStringArr(d.Get("tags").([]interface{})))
+	}
+
+	_, resp, err := client.VRFsApi.UpdateVrfRouteById(ctx, d.Id()).VrfRouteUpdateInput(updateRequest).Execute()
+	if err != nil {
+		return friendlyErrorForMetalGo(err, resp)
+	}
+
+	if err := waitForActiveMetalVRFRoute(ctx, client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+
+	return resourceMetalVRFRouteRead(ctx, d, meta)
+}
+
+func resourceMetalVRFRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	route, resp, err := client.VRFsApi.FindVrfRouteById(ctx, d.Id()).
+		Include([]string{"vrf", "metal_gateway", "virtual_network"}).Execute()
+	if err != nil {
+		err = friendlyErrorForMetalGo(err, resp)
+		if isNotFound(err) || isForbidden(err) {
+			log.Printf("[WARN] VRF route (%s) not accessible, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+		return err
+	}
+
+	return setMap(d, map[string]interface{}{
+		"vrf_id":             route.Vrf.GetId(),
+		"prefix":             route.GetPrefix(),
+		"next_hop":           route.GetNextHop(),
+		"tags":               route.GetTags(),
+		"type":               string(route.GetType()),
+		"status":             string(route.GetStatus()),
+		"metal_gateway_id":   route.MetalGateway.GetId(),
+		"virtual_network_id": route.VirtualNetwork.GetId(),
+	})
+}
+
+func resourceMetalVRFRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	meta.(*Config).addModuleToMetalGoUserAgent(d)
+	client := meta.(*Config).metalgo
+
+	_, resp, err := client.VRFsApi.DeleteVrfRouteById(ctx, d.Id()).Execute()
+	if err != nil {
+		err = friendlyErrorForMetalGo(err, resp)
+		if isNotFound(err) || isForbidden(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	deleteWaiter := getMetalVRFRouteStateWaiter(
+		client,
+		d.Id(),
+		d.Timeout(schema.TimeoutDelete),
+		[]string{string(metalv1.VRFROUTESTATUS_DELETING), string(metalv1.VRFROUTESTATUS_ACTIVE)},
+		[]string{},
+	)
+	if _, err = deleteWaiter.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error deleting VRF route %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForActiveMetalVRFRoute(ctx context.Context, client *metalv1.APIClient, id string, timeout time.Duration) error {
+	waiter := getMetalVRFRouteStateWaiter(
+		client,
+		id,
+		timeout,
+		[]string{string(metalv1.VRFROUTESTATUS_PENDING)},
+		[]string{string(metalv1.VRFROUTESTATUS_ACTIVE)},
+	)
+	if _, err := waiter.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for VRF route %s to become active: %s", id, err)
+	}
+	return nil
+}
+
+// getMetalVRFRouteStateWaiter waits for the VRF route to reach one of the
+// target statuses. An empty target waits for the route to be removed.
+func getMetalVRFRouteStateWaiter(client *metalv1.APIClient, id string, timeout time.Duration, pending, target []string) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			route, resp, err := client.VRFsApi.FindVrfRouteById(context.Background(), id).Execute()
+			if err != nil {
+				err = friendlyErrorForMetalGo(err, resp)
+				if isNotFound(err) {
+					return nil, "", nil
+				}
+				return nil, "", err
+			}
+			if route.GetStatus() == metalv1.VRFROUTESTATUS_ERROR {
+				return route, "", fmt.Errorf("VRF route %s is in error status", id)
+			}
+			return route, string(route.GetStatus()), nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,72 @@
+package equinix
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetalVRFRoute_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ExternalProviders: testExternalProviders,
+		Providers:         testAccProviders,
+		CheckDestroy:      testAccMetalVRFCheckDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMetalVRFRouteConfig(rInt, "10.0.0.0/24", "192.168.100.2"),
+				ExpectError: regexp.MustCompile("prefix 10.0.0.0/24 is not within the ip_ranges"),
+			},
+			{
+				Config:      testAccMetalVRFRouteConfig(rInt, "192.168.100.64/26", "10.0.0.1"),
+				ExpectError: regexp.MustCompile("next_hop 10.0.0.1 is not within the ip_ranges"),
+			},
+			{
+				Config: testAccMetalVRFRouteConfig(rInt, "192.168.100.64/26", "192.168.100.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_vrf_route.test", "vrf_id",
+						"equinix_metal_vrf.test", "id"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_route.test", "prefix", "192.168.100.64/26"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_route.test", "next_hop", "192.168.100.2"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_route.test", "status", "active"),
+				),
+			},
+			{
+				Config: testAccMetalVRFRouteConfig(rInt, "192.168.100.64/26", "192.168.100.3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_route.test", "next_hop", "192.168.100.3"),
+					resource.TestCheckResourceAttr(
+						"equinix_metal_vrf_route.test", "status", "active"),
+				),
+			},
+			{
+				ResourceName:      "equinix_metal_vrf_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMetalVRFRouteConfig(r int, prefix, nextHop string) string {
+	return testAccMetalVRFConfig_withGateway(r) + fmt.Sprintf(`
+
+resource "equinix_metal_vrf_route" "test" {
+	vrf_id   = equinix_metal_vrf.test.id
+	prefix   = "%s"
+	next_hop = "%s"
+
+	depends_on = [equinix_metal_gateway.test]
+}
+`, prefix, nextHop)
+}
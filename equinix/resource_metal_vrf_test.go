@@ -0,0 +1,36 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalVRF_ipRangesContain(t *testing.T) {
+	// given
+	ipRanges := []string{"192.168.100.0/25", "2604:1380:4641:a00::/56"}
+	testCases := []struct {
+		prefix   string
+		expected bool
+	}{
+		{"192.168.100.0/25", true},
+		{"192.168.100.8/29", true},
+		{"192.168.100.126", true},
+		{"192.168.100.128/29", false},
+		{"192.168.0.0/16", false},
+		{"10.0.0.1", false},
+		{"2604:1380:4641:a00::/64", true},
+		{"2604:1380:4641:a00::1", true},
+		{"2604:1380:4641:b00::/64", false},
+	}
+	for _, tc := range testCases {
+		// when
+		contained, err := vrfIPRangesContain(ipRanges, tc.prefix)
+		// then
+		assert.Nil(t, err, "Prefix %s is valid", tc.prefix)
+		assert.Equal(t, tc.expected, contained, "Prefix %s containment matches", tc.prefix)
+	}
+
+	_, err := vrfIPRangesContain(ipRanges, "not-an-ip")
+	assert.NotNil(t, err, "Invalid prefix is reported")
+}
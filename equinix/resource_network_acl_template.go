@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 
 	"github.com/equinix/ne-go"
 	"github.com/equinix/rest-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,6 +23,7 @@ var networkACLTemplateSchemaNames = map[string]string{
 	"DeviceUUID":      "device_id",
 	"DeviceACLStatus": "device_acl_status",
 	"InboundRules":    "inbound_rule",
+	"OutboundRules":   "outbound_rule",
 	"DeviceDetails":   "device_details",
 }
 
@@ -31,7 +34,8 @@ var networkACLTemplateDescriptions = map[string]string{
 	"MetroCode":       "ACL template location metro code",
 	"DeviceUUID":      "Identifier of a network device where template was applied",
 	"DeviceACLStatus": "Status of ACL template provisioning process on a device, where template was applied",
-	"InboundRules":    "One or more rules to specify allowed inbound traffic. Rules are ordered, matching traffic rule stops processing subsequent ones.",
+	"InboundRules":    "One or more rules to specify allowed inbound traffic. Rules are ordered by sequence number, matching traffic rule stops processing subsequent ones.",
+	"OutboundRules":   "Rules to specify allowed outbound traffic. Rules are ordered by sequence number, matching traffic rule stops processing subsequent ones.",
 	"DeviceDetails":   "Device Details to which ACL template is assigned to. ",
 }
 
@@ -47,7 +51,7 @@ var networkACLTemplateInboundRuleSchemaNames = map[string]string{
 }
 
 var networkACLTemplateInboundRuleDescriptions = map[string]string{
-	"SeqNo":       "Inbound rule sequence number",
+	"SeqNo":       "Inbound rule sequence number, unique among inbound rules. Defaults to the position of the rule",
 	"SrcType":     "Type of traffic source used in a given inbound rule",
 	"Subnets":     "Inbound traffic source IP subnets in CIDR format",
 	"Subnet":      "Inbound traffic source IP subnet in CIDR format",
@@ -57,6 +61,24 @@ var networkACLTemplateInboundRuleDescriptions = map[string]string{
 	"Description": "Inbound rule description, up to 200 characters",
 }
 
+var networkACLTemplateOutboundRuleSchemaNames = map[string]string{
+	"SeqNo":       "sequence_number",
+	"Subnet":      "subnet",
+	"Protocol":    "protocol",
+	"SrcPort":     "src_port",
+	"DstPort":     "dst_port",
+	"Description": "description",
+}
+
+var networkACLTemplateOutboundRuleDescriptions = map[string]string{
+	"SeqNo":       "Outbound rule sequence number, unique among outbound rules",
+	"Subnet":      "Outbound traffic destination IP subnet in CIDR format",
+	"Protocol":    "Outbound traffic protocol. One of: `IP`, `TCP`, `UDP`",
+	"SrcPort":     "Outbound traffic source ports. Either up to 10, comma separated ports or port range or any word",
+	"DstPort":     "Outbound traffic destination ports. Either up to 10, comma separated ports or port range or any word",
+	"Description": "Outbound rule description, up to 200 characters",
+}
+
 var networkACLTemplateDeviceDetailSchemaNames = map[string]string{
 	"UUID":      "uuid",
 	"Name":      "name",
@@ -84,8 +106,9 @@ func resourceNetworkACLTemplate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Schema:      createNetworkACLTemplateSchema(),
-		Description: "Resource allows creation and management of Equinix Network Edge device Access Control List templates",
+		Schema:        createNetworkACLTemplateSchema(),
+		CustomizeDiff: checkNetworkACLTemplateRules,
+		Description:   "Resource allows creation and management of Equinix Network Edge device Access Control List templates",
 	}
 }
 
@@ -127,14 +150,24 @@ func createNetworkACLTemplateSchema() map[string]*schema.Schema {
 			Description: networkACLTemplateDescriptions["DeviceACLStatus"],
 		},
 		networkACLTemplateSchemaNames["InboundRules"]: {
-			Type:     schema.TypeList,
+			Type:     schema.TypeSet,
 			Required: true,
 			MinItems: 1,
 			Elem: &schema.Resource{
 				Schema: createNetworkACLTemplateInboundRuleSchema(),
 			},
+			Set:         hashACLTemplateRule,
 			Description: networkACLTemplateDescriptions["InboundRules"],
 		},
+		networkACLTemplateSchemaNames["OutboundRules"]: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: createNetworkACLTemplateOutboundRuleSchema(),
+			},
+			Set:         hashACLTemplateRule,
+			Description: networkACLTemplateDescriptions["OutboundRules"],
+		},
 		networkACLTemplateSchemaNames["DeviceDetails"]: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -149,9 +182,10 @@ func createNetworkACLTemplateSchema() map[string]*schema.Schema {
 func createNetworkACLTemplateInboundRuleSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		networkACLTemplateInboundRuleSchemaNames["SeqNo"]: {
-			Type:        schema.TypeInt,
-			Computed:    true,
-			Description: networkACLTemplateInboundRuleDescriptions["SeqNo"],
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  networkACLTemplateInboundRuleDescriptions["SeqNo"],
 		},
 		networkACLTemplateInboundRuleSchemaNames["SrcType"]: {
 			Type:        schema.TypeString,
@@ -203,6 +237,47 @@ func createNetworkACLTemplateInboundRuleSchema() map[string]*schema.Schema {
 	}
 }
 
+func createNetworkACLTemplateOutboundRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		networkACLTemplateOutboundRuleSchemaNames["SeqNo"]: {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  networkACLTemplateOutboundRuleDescriptions["SeqNo"],
+		},
+		networkACLTemplateOutboundRuleSchemaNames["Subnet"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsCIDR,
+			Description:  networkACLTemplateOutboundRuleDescriptions["Subnet"],
+		},
+		networkACLTemplateOutboundRuleSchemaNames["Protocol"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"IP", "TCP", "UDP"}, false),
+			Description:  networkACLTemplateOutboundRuleDescriptions["Protocol"],
+		},
+		networkACLTemplateOutboundRuleSchemaNames["SrcPort"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: stringIsPortDefinition(),
+			Description:  networkACLTemplateOutboundRuleDescriptions["SrcPort"],
+		},
+		networkACLTemplateOutboundRuleSchemaNames["DstPort"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: stringIsPortDefinition(),
+			Description:  networkACLTemplateOutboundRuleDescriptions["DstPort"],
+		},
+		networkACLTemplateOutboundRuleSchemaNames["Description"]: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 200),
+			Description:  networkACLTemplateOutboundRuleDescriptions["Description"],
+		},
+	}
+}
+
 func networkACLTemplateDeviceDetailsSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		networkACLTemplateDeviceDetailSchemaNames["UUID"]: {
@@ -233,6 +308,11 @@ func resourceNetworkACLTemplateCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 	d.SetId(ne.StringValue(uuid))
+	if outboundRules := createACLTemplateOutboundRules(d); len(outboundRules) > 0 {
+		if err := replaceNetworkACLTemplateOutboundRules(client, d.Id(), template, outboundRules); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	diags = append(diags, resourceNetworkACLTemplateRead(ctx, d, m)...)
 	return diags
 }
@@ -251,7 +331,11 @@ func resourceNetworkACLTemplateRead(ctx context.Context, d *schema.ResourceData,
 		}
 		return diag.FromErr(err)
 	}
-	if err := updateACLTemplateResource(template, d); err != nil {
+	outboundRules, err := getNetworkACLTemplateOutboundRules(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateACLTemplateResource(template, outboundRules, d); err != nil {
 		return diag.FromErr(err)
 	}
 	return diags
@@ -262,7 +346,13 @@ func resourceNetworkACLTemplateUpdate(ctx context.Context, d *schema.ResourceDat
 	m.(*Config).addModuleToNEUserAgent(&client, d)
 	var diags diag.Diagnostics
 	template := createACLTemplate(d)
-	if err := client.ReplaceACLTemplate(d.Id(), template); err != nil {
+	// replacing a template with ne-go drops its outbound rules
+	o, n := d.GetChange(networkACLTemplateSchemaNames["OutboundRules"])
+	if o.(*schema.Set).Len() > 0 || n.(*schema.Set).Len() > 0 {
+		if err := replaceNetworkACLTemplateOutboundRules(client, d.Id(), template, createACLTemplateOutboundRules(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := client.ReplaceACLTemplate(d.Id(), template); err != nil {
 		return diag.FromErr(err)
 	}
 	diags = append(diags, resourceNetworkACLTemplateRead(ctx, d, m)...)
@@ -296,12 +386,19 @@ func createACLTemplate(d *schema.ResourceData) ne.ACLTemplate {
 		template.MetroCode = ne.String(v.(string))
 	}
 	if v, ok := d.GetOk(networkACLTemplateSchemaNames["InboundRules"]); ok {
-		template.InboundRules = expandACLTemplateInboundRules(v.([]interface{}))
+		template.InboundRules = expandACLTemplateInboundRules(v.(*schema.Set).List())
 	}
 	return template
 }
 
-func updateACLTemplateResource(template *ne.ACLTemplate, d *schema.ResourceData) error {
+func createACLTemplateOutboundRules(d *schema.ResourceData) []ne.ACLTemplateInboundRule {
+	if v, ok := d.GetOk(networkACLTemplateSchemaNames["OutboundRules"]); ok {
+		return expandACLTemplateOutboundRules(v.(*schema.Set).List())
+	}
+	return nil
+}
+
+func updateACLTemplateResource(template *ne.ACLTemplate, outboundRules []ne.ACLTemplateInboundRule, d *schema.ResourceData) error {
 	if err := d.Set(networkACLTemplateSchemaNames["UUID"], template.UUID); err != nil {
 		return fmt.Errorf("error reading %s: %s", networkACLTemplateSchemaNames["UUID"], err)
 	}
@@ -316,11 +413,14 @@ func updateACLTemplateResource(template *ne.ACLTemplate, d *schema.ResourceData)
 	}
 	var inboundRules []ne.ACLTemplateInboundRule
 	if v, ok := d.GetOk(networkACLTemplateSchemaNames["InboundRules"]); ok {
-		inboundRules = expandACLTemplateInboundRules(v.([]interface{}))
+		inboundRules = expandACLTemplateInboundRules(v.(*schema.Set).List())
 	}
 	if err := d.Set(networkACLTemplateSchemaNames["InboundRules"], flattenACLTemplateInboundRules(inboundRules, template.InboundRules)); err != nil {
 		return fmt.Errorf("error reading %s: %s", networkACLTemplateSchemaNames["InboundRules"], err)
 	}
+	if err := d.Set(networkACLTemplateSchemaNames["OutboundRules"], flattenACLTemplateOutboundRules(outboundRules)); err != nil {
+		return fmt.Errorf("error reading %s: %s", networkACLTemplateSchemaNames["OutboundRules"], err)
+	}
 	if err := d.Set(networkACLTemplateSchemaNames["DeviceDetails"], flattenACLTemplateDeviceDetails(template.DeviceDetails)); err != nil {
 		return fmt.Errorf("error reading %s: %s", networkACLTemplateSchemaNames["DeviceDetails"], err)
 	}
@@ -328,12 +428,16 @@ func updateACLTemplateResource(template *ne.ACLTemplate, d *schema.ResourceData)
 	return nil
 }
 
+// expandACLTemplateInboundRules expands inbound rules ordered by sequence
+// number.
 func expandACLTemplateInboundRules(rules []interface{}) []ne.ACLTemplateInboundRule {
 	transformed := make([]ne.ACLTemplateInboundRule, len(rules))
 	for i := range rules {
 		ruleMap := rules[i].(map[string]interface{})
 		rule := ne.ACLTemplateInboundRule{}
-		rule.SeqNo = ne.Int(i + 1)
+		if v, ok := ruleMap[networkACLTemplateInboundRuleSchemaNames["SeqNo"]]; ok {
+			rule.SeqNo = ne.Int(v.(int))
+		}
 		if v, ok := ruleMap[networkACLTemplateInboundRuleSchemaNames["Subnets"]]; ok {
 			rule.Subnets = expandListToStringList(v.([]interface{}))
 		}
@@ -354,9 +458,43 @@ func expandACLTemplateInboundRules(rules []interface{}) []ne.ACLTemplateInboundR
 		}
 		transformed[i] = rule
 	}
+	sortACLTemplateRules(transformed)
+	return transformed
+}
+
+// expandACLTemplateOutboundRules expands outbound rules to the ne-go inbound
+// rule model, as both rule types have the same attributes.
+func expandACLTemplateOutboundRules(rules []interface{}) []ne.ACLTemplateInboundRule {
+	transformed := make([]ne.ACLTemplateInboundRule, len(rules))
+	for i := range rules {
+		ruleMap := rules[i].(map[string]interface{})
+		transformed[i] = ne.ACLTemplateInboundRule{
+			SeqNo:    ne.Int(ruleMap[networkACLTemplateOutboundRuleSchemaNames["SeqNo"]].(int)),
+			Subnet:   ne.String(ruleMap[networkACLTemplateOutboundRuleSchemaNames["Subnet"]].(string)),
+			Protocol: ne.String(ruleMap[networkACLTemplateOutboundRuleSchemaNames["Protocol"]].(string)),
+			SrcPort:  ne.String(ruleMap[networkACLTemplateOutboundRuleSchemaNames["SrcPort"]].(string)),
+			DstPort:  ne.String(ruleMap[networkACLTemplateOutboundRuleSchemaNames["DstPort"]].(string)),
+		}
+		if v, ok := ruleMap[networkACLTemplateOutboundRuleSchemaNames["Description"]]; ok && v.(string) != "" {
+			transformed[i].Description = ne.String(v.(string))
+		}
+	}
+	sortACLTemplateRules(transformed)
 	return transformed
 }
 
+func sortACLTemplateRules(rules []ne.ACLTemplateInboundRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return ne.IntValue(rules[i].SeqNo) < ne.IntValue(rules[j].SeqNo)
+	})
+}
+
+// hashACLTemplateRule identifies inbound and outbound rules by their sequence
+// number, so that changing a rule shows only that rule in the plan.
+func hashACLTemplateRule(v interface{}) int {
+	return schema.HashInt(v.(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["SeqNo"]])
+}
+
 func flattenACLTemplateInboundRules(existingRules []ne.ACLTemplateInboundRule, rules []ne.ACLTemplateInboundRule) interface{} {
 	setSubnets := checkExistingSubnets(existingRules)
 	transformed := make([]interface{}, len(rules))
@@ -377,6 +515,21 @@ func flattenACLTemplateInboundRules(existingRules []ne.ACLTemplateInboundRule, r
 	return transformed
 }
 
+func flattenACLTemplateOutboundRules(rules []ne.ACLTemplateInboundRule) interface{} {
+	transformed := make([]interface{}, len(rules))
+	for i := range rules {
+		transformed[i] = map[string]interface{}{
+			networkACLTemplateOutboundRuleSchemaNames["SeqNo"]:       rules[i].SeqNo,
+			networkACLTemplateOutboundRuleSchemaNames["Subnet"]:      rules[i].Subnet,
+			networkACLTemplateOutboundRuleSchemaNames["Protocol"]:    rules[i].Protocol,
+			networkACLTemplateOutboundRuleSchemaNames["SrcPort"]:     rules[i].SrcPort,
+			networkACLTemplateOutboundRuleSchemaNames["DstPort"]:     rules[i].DstPort,
+			networkACLTemplateOutboundRuleSchemaNames["Description"]: rules[i].Description,
+		}
+	}
+	return transformed
+}
+
 func checkExistingSubnets(existingRules []ne.ACLTemplateInboundRule) bool {
 	for i := range existingRules {
 		if existingRules[i].Subnets != nil && len(existingRules[i].Subnets) > 0 {
@@ -397,3 +550,56 @@ func flattenACLTemplateDeviceDetails(rules []ne.ACLTemplateDeviceDetails) interf
 	}
 	return transformed
 }
+
+// checkNetworkACLTemplateRules detects rules with conflicting sequence numbers
+// and rules shadowed by earlier rules at plan time. Rules with the same
+// sequence number collapse into one element of the rule set, so conflicts are
+// looked up in the raw configuration.
+func checkNetworkACLTemplateRules(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() {
+		if seqNo, ok := findACLTemplateRuleSeqNoConflict(rawConfig.GetAttr(networkACLTemplateSchemaNames["InboundRules"])); ok {
+			return fmt.Errorf("inbound rules have conflicting sequence number %d", seqNo)
+		}
+		if seqNo, ok := findACLTemplateRuleSeqNoConflict(rawConfig.GetAttr(networkACLTemplateSchemaNames["OutboundRules"])); ok {
+			return fmt.Errorf("outbound rules have conflicting sequence number %d", seqNo)
+		}
+	}
+	if d.NewValueKnown(networkACLTemplateSchemaNames["InboundRules"]) {
+		rules := expandACLTemplateInboundRules(d.Get(networkACLTemplateSchemaNames["InboundRules"]).(*schema.Set).List())
+		if err := checkACLTemplateRules("inbound", rules); err != nil {
+			return err
+		}
+	}
+	if d.NewValueKnown(networkACLTemplateSchemaNames["OutboundRules"]) {
+		rules := expandACLTemplateOutboundRules(d.Get(networkACLTemplateSchemaNames["OutboundRules"]).(*schema.Set).List())
+		if err := checkACLTemplateRules("outbound", rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findACLTemplateRuleSeqNoConflict returns a sequence number used by more
+// than one of the configured rules.
+func findACLTemplateRuleSeqNoConflict(rules cty.Value) (int, bool) {
+	if rules.IsNull() || !rules.IsKnown() || !rules.CanIterateElements() {
+		return 0, false
+	}
+	seen := make(map[int]bool)
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if rule.IsNull() || !rule.IsKnown() {
+			continue
+		}
+		v := rule.GetAttr(networkACLTemplateInboundRuleSchemaNames["SeqNo"])
+		if v.IsNull() || !v.IsKnown() {
+			continue
+		}
+		seqNo, _ := v.AsBigFloat().Int64()
+		if seen[int(seqNo)] {
+			return int(seqNo), true
+		}
+		seen[int(seqNo)] = true
+	}
+	return 0, false
+}
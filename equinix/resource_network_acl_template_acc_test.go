@@ -68,6 +68,14 @@ func TestAccNetworkACLTemplate(t *testing.T) {
 		"inbound_rule_3_protocol":    "UDP",
 		"inbound_rule_3_src_port":    "any",
 		"inbound_rule_3_dst_port":    "any",
+		"outbound_rule_1_subnet":     "0.0.0.0/0",
+		"outbound_rule_1_protocol":   "TCP",
+		"outbound_rule_1_src_port":   "any",
+		"outbound_rule_1_dst_port":   "443",
+		"outbound_rule_2_subnet":     "10.0.0.0/16",
+		"outbound_rule_2_protocol":   "UDP",
+		"outbound_rule_2_src_port":   "any",
+		"outbound_rule_2_dst_port":   "53",
 	}
 	contextWithChanges := copyMap(context)
 	contextWithChanges["description"] = randString(50)
@@ -75,6 +83,7 @@ func TestAccNetworkACLTemplate(t *testing.T) {
 	contextWithChanges["inbound_rule_3_subnet"] = "4.4.4.4/32"
 	contextWithChanges["inbound_rule_3_protocol"] = "TCP"
 	contextWithChanges["inbound_rule_3_dst_port"] = "2048"
+	contextWithChanges["outbound_rule_2_dst_port"] = "123"
 	resourceName := "equinix_network_acl_template." + context["resourceName"].(string)
 	var template ne.ACLTemplate
 	resource.ParallelTest(t, resource.TestCase{
@@ -86,7 +95,14 @@ func TestAccNetworkACLTemplate(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccNetworkACLTemplateExists(resourceName, &template),
 					testAccNetworkACLTemplateAttributes(&template, context),
+					testAccNetworkACLTemplateOutboundRules(resourceName, context),
 					resource.TestCheckResourceAttrSet(resourceName, "uuid"),
+					resource.TestCheckResourceAttr(resourceName, "outbound_rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "outbound_rule.*", map[string]string{
+						"sequence_number": "20",
+						"subnet":          context["outbound_rule_2_subnet"].(string),
+						"dst_port":        context["outbound_rule_2_dst_port"].(string),
+					}),
 				),
 			},
 			{
@@ -99,7 +115,13 @@ func TestAccNetworkACLTemplate(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccNetworkACLTemplateExists(resourceName, &template),
 					testAccNetworkACLTemplateAttributes(&template, contextWithChanges),
+					testAccNetworkACLTemplateOutboundRules(resourceName, contextWithChanges),
 					resource.TestCheckResourceAttrSet(resourceName, "uuid"),
+					resource.TestCheckResourceAttr(resourceName, "outbound_rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "outbound_rule.*", map[string]string{
+						"sequence_number": "20",
+						"dst_port":        contextWithChanges["outbound_rule_2_dst_port"].(string),
+					}),
 				),
 			},
 		},
@@ -113,6 +135,7 @@ resource "equinix_network_acl_template" "%{resourceName}" {
   description   = "%{description}"
 
   inbound_rule {
+    sequence_number = 1
     subnet   = "%{inbound_rule_1_subnet}"
 	protocol = "%{inbound_rule_1_protocol}"
 	src_port = "%{inbound_rule_1_src_port}"
@@ -121,6 +144,7 @@ resource "equinix_network_acl_template" "%{resourceName}" {
   }
 
   inbound_rule {
+	sequence_number = 2
 	subnet   = "%{inbound_rule_2_subnet}"
 	protocol = "%{inbound_rule_2_protocol}"
 	src_port = "%{inbound_rule_2_src_port}"
@@ -128,11 +152,28 @@ resource "equinix_network_acl_template" "%{resourceName}" {
   }
 
   inbound_rule {
+	sequence_number = 3
 	subnet   = "%{inbound_rule_3_subnet}"
 	protocol = "%{inbound_rule_3_protocol}"
 	src_port = "%{inbound_rule_3_src_port}"
 	dst_port = "%{inbound_rule_3_dst_port}"
   }
+
+  outbound_rule {
+	sequence_number = 1
+	subnet   = "%{outbound_rule_1_subnet}"
+	protocol = "%{outbound_rule_1_protocol}"
+	src_port = "%{outbound_rule_1_src_port}"
+	dst_port = "%{outbound_rule_1_dst_port}"
+  }
+
+  outbound_rule {
+	sequence_number = 20
+	subnet   = "%{outbound_rule_2_subnet}"
+	protocol = "%{outbound_rule_2_protocol}"
+	src_port = "%{outbound_rule_2_src_port}"
+	dst_port = "%{outbound_rule_2_dst_port}"
+  }
 }
 `, ctx)
 }
@@ -190,3 +231,36 @@ func testAccNetworkACLTemplateAttributes(template *ne.ACLTemplate, ctx map[strin
 		return nil
 	}
 }
+
+func testAccNetworkACLTemplateOutboundRules(resourceName string, ctx map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		client := testAccProvider.Meta().(*Config).ne
+		rules, err := getNetworkACLTemplateOutboundRules(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error when fetching outbound rules of ACL template '%s': %s", rs.Primary.ID, err)
+		}
+		if len(rules) != 2 {
+			return fmt.Errorf("number of outbound rules does not match %v - %v", len(rules), 2)
+		}
+		sortACLTemplateRules(rules)
+		for i, seqNo := range []int{1, 20} {
+			if ne.IntValue(rules[i].SeqNo) != seqNo {
+				return fmt.Errorf("outbound_rule %d seqNo does not match %v - %v", i+1, ne.IntValue(rules[i].SeqNo), seqNo)
+			}
+			if v, ok := ctx[fmt.Sprintf("outbound_rule_%d_subnet", i+1)]; ok && ne.StringValue(rules[i].Subnet) != v.(string) {
+				return fmt.Errorf("outbound_rule %d subnet does not match %v - %v", i+1, ne.StringValue(rules[i].Subnet), v)
+			}
+			if v, ok := ctx[fmt.Sprintf("outbound_rule_%d_protocol", i+1)]; ok && ne.StringValue(rules[i].Protocol) != v.(string) {
+				return fmt.Errorf("outbound_rule %d protocol does not match %v - %v", i+1, ne.StringValue(rules[i].Protocol), v)
+			}
+			if v, ok := ctx[fmt.Sprintf("outbound_rule_%d_dst_port", i+1)]; ok && ne.StringValue(rules[i].DstPort) != v.(string) {
+				return fmt.Errorf("outbound_rule %d dst_port does not match %v - %v", i+1, ne.StringValue(rules[i].DstPort), v)
+			}
+		}
+		return nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/equinix/ne-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,32 +86,44 @@ func TestNetworkACLTemplate_updateResourceData(t *testing.T) {
 			},
 		},
 	}
+	outboundRules := []ne.ACLTemplateInboundRule{
+		{
+			SeqNo:    ne.Int(1),
+			Subnet:   ne.String("0.0.0.0/0"),
+			Protocol: ne.String("TCP"),
+			SrcPort:  ne.String("any"),
+			DstPort:  ne.String("443"),
+		},
+	}
 	// when
-	err := updateACLTemplateResource(input, d)
+	err := updateACLTemplateResource(input, outboundRules, d)
 	// then
 	assert.Nil(t, err, "Update of resource data does not return error")
 	assert.Equal(t, ne.StringValue(input.Name), d.Get(networkACLTemplateSchemaNames["Name"]), "Name matches")
 	assert.Equal(t, ne.StringValue(input.Description), d.Get(networkACLTemplateSchemaNames["Description"]), "Description matches")
 	assert.Equal(t, ne.StringValue(input.MetroCode), d.Get(networkACLTemplateSchemaNames["MetroCode"]), "MetroCode matches")
-	assert.Equal(t, input.InboundRules, expandACLTemplateInboundRules(d.Get(networkACLTemplateSchemaNames["InboundRules"]).([]interface{})), "InboundRules matches")
+	assert.Equal(t, input.InboundRules, expandACLTemplateInboundRules(d.Get(networkACLTemplateSchemaNames["InboundRules"]).(*schema.Set).List()), "InboundRules matches")
+	assert.Equal(t, outboundRules, expandACLTemplateOutboundRules(d.Get(networkACLTemplateSchemaNames["OutboundRules"]).(*schema.Set).List()), "OutboundRules matches")
 }
 
 func TestNetworkACLTemplate_expandInboundRules(t *testing.T) {
 	// given
 	input := []interface{}{
 		map[string]interface{}{
+			networkACLTemplateInboundRuleSchemaNames["SeqNo"]:    2,
+			networkACLTemplateInboundRuleSchemaNames["Subnet"]:   "3.3.3.3/32",
+			networkACLTemplateInboundRuleSchemaNames["Protocol"]: "IP",
+			networkACLTemplateInboundRuleSchemaNames["SrcPort"]:  "any",
+			networkACLTemplateInboundRuleSchemaNames["DstPort"]:  "any",
+		},
+		map[string]interface{}{
+			networkACLTemplateInboundRuleSchemaNames["SeqNo"]:       1,
 			networkACLTemplateInboundRuleSchemaNames["Subnets"]:     []interface{}{"10.0.0.0/24", "1.1.1.1/32"},
 			networkACLTemplateInboundRuleSchemaNames["Protocol"]:    "TCP",
 			networkACLTemplateInboundRuleSchemaNames["SrcPort"]:     "any",
 			networkACLTemplateInboundRuleSchemaNames["DstPort"]:     "8080",
 			networkACLTemplateInboundRuleSchemaNames["Description"]: "description of inbound rule",
 		},
-		map[string]interface{}{
-			networkACLTemplateInboundRuleSchemaNames["Subnet"]:   "3.3.3.3/32",
-			networkACLTemplateInboundRuleSchemaNames["Protocol"]: "IP",
-			networkACLTemplateInboundRuleSchemaNames["SrcPort"]:  "any",
-			networkACLTemplateInboundRuleSchemaNames["DstPort"]:  "any",
-		},
 	}
 
 	var nilSubnet *string = nil
@@ -119,26 +132,26 @@ func TestNetworkACLTemplate_expandInboundRules(t *testing.T) {
 	expected := []ne.ACLTemplateInboundRule{
 		{
 			SeqNo:       ne.Int(1),
-			Subnets:     expandListToStringList(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Subnets"]].([]interface{})),
+			Subnets:     expandListToStringList(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Subnets"]].([]interface{})),
 			Subnet:      nilSubnet,
-			Protocol:    ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Protocol"]].(string)),
-			SrcPort:     ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["SrcPort"]].(string)),
-			DstPort:     ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["DstPort"]].(string)),
-			Description: ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Description"]].(string)),
+			Protocol:    ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Protocol"]].(string)),
+			SrcPort:     ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["SrcPort"]].(string)),
+			DstPort:     ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["DstPort"]].(string)),
+			Description: ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Description"]].(string)),
 		},
 		{
 			SeqNo:    ne.Int(2),
 			Subnets:  nilSubnets,
-			Subnet:   ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Subnet"]].(string)),
-			Protocol: ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Protocol"]].(string)),
-			SrcPort:  ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["SrcPort"]].(string)),
-			DstPort:  ne.String(input[1].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["DstPort"]].(string)),
+			Subnet:   ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Subnet"]].(string)),
+			Protocol: ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["Protocol"]].(string)),
+			SrcPort:  ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["SrcPort"]].(string)),
+			DstPort:  ne.String(input[0].(map[string]interface{})[networkACLTemplateInboundRuleSchemaNames["DstPort"]].(string)),
 		},
 	}
 	// when
 	result := expandACLTemplateInboundRules(input)
 	// then
-	assert.Equal(t, expected, result, "Expanded ACL template inbound rules matches expected result")
+	assert.Equal(t, expected, result, "Expanded ACL template inbound rules are ordered by sequence number")
 }
 
 func TestNetworkACLTemplate_flattenInboundRules(t *testing.T) {
@@ -220,3 +233,42 @@ func TestNetworkACLTemplate_flattenDeviceDetails(t *testing.T) {
 	// then
 	assert.Equal(t, expected, result, "Flattened ACL template Device Details match expected result")
 }
+
+func TestNetworkACLTemplate_hashRule(t *testing.T) {
+	// given
+	rule := map[string]interface{}{
+		networkACLTemplateInboundRuleSchemaNames["SeqNo"]:    10,
+		networkACLTemplateInboundRuleSchemaNames["Subnet"]:   "10.0.0.0/24",
+		networkACLTemplateInboundRuleSchemaNames["Protocol"]: "TCP",
+	}
+	changed := copyMap(rule)
+	changed[networkACLTemplateInboundRuleSchemaNames["Protocol"]] = "UDP"
+	renumbered := copyMap(rule)
+	renumbered[networkACLTemplateInboundRuleSchemaNames["SeqNo"]] = 20
+	// when
+	hash := hashACLTemplateRule(rule)
+	// then
+	assert.Equal(t, hash, hashACLTemplateRule(changed), "Rules with the same sequence number have the same hash")
+	assert.NotEqual(t, hash, hashACLTemplateRule(renumbered), "Rules with different sequence numbers have different hashes")
+}
+
+func TestNetworkACLTemplate_findRuleSeqNoConflict(t *testing.T) {
+	// given
+	rule := func(seqNo int, subnet string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			networkACLTemplateInboundRuleSchemaNames["SeqNo"]:  cty.NumberIntVal(int64(seqNo)),
+			networkACLTemplateInboundRuleSchemaNames["Subnet"]: cty.StringVal(subnet),
+		})
+	}
+	valid := cty.SetVal([]cty.Value{rule(1, "10.0.0.0/24"), rule(2, "10.0.1.0/24")})
+	conflicting := cty.SetVal([]cty.Value{rule(1, "10.0.0.0/24"), rule(1, "10.0.1.0/24")})
+	// when
+	_, validConflict := findACLTemplateRuleSeqNoConflict(valid)
+	seqNo, conflict := findACLTemplateRuleSeqNoConflict(conflicting)
+	_, nullConflict := findACLTemplateRuleSeqNoConflict(cty.NullVal(valid.Type()))
+	// then
+	assert.False(t, validConflict, "Rules with unique sequence numbers don't conflict")
+	assert.True(t, conflict, "Rules with the same sequence number conflict")
+	assert.Equal(t, 1, seqNo, "Conflicting sequence number is returned")
+	assert.False(t, nullConflict, "Missing rules don't conflict")
+}
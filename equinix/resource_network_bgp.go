@@ -3,6 +3,7 @@ package equinix
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -23,21 +24,39 @@ var networkBGPSchemaNames = map[string]string{
 	"RemoteIPAddress":    "remote_ip_address",
 	"RemoteASN":          "remote_asn",
 	"AuthenticationKey":  "authentication_key",
+	"EBGPMultihop":       "ebgp_multihop",
+	"KeepaliveTimer":     "keepalive_timer",
+	"HoldTimer":          "hold_timer",
+	"PrefixLimit":        "prefix_limit",
+	"BFDEnabled":         "bfd_enabled",
+	"BFDInterval":        "bfd_interval",
+	"BFDMultiplier":      "bfd_multiplier",
 	"State":              "state",
 	"ProvisioningStatus": "provisioning_status",
+	"ReceivedPrefixes":   "received_prefixes",
+	"AdvertisedPrefixes": "advertised_prefixes",
 }
 
 var networkBGPDescriptions = map[string]string{
 	"UUID":               "BGP peering configuration unique identifier",
 	"ConnectionUUID":     "Identifier of a connection established between network device and remote service provider that will be used for peering",
 	"DeviceUUID":         "Unique identifier of a network device that is a local peer in a given BGP peering configuration",
-	"LocalIPAddress":     "IP address in CIDR format of a local device",
+	"LocalIPAddress":     "IPv4 or IPv6 address in CIDR format of a local device",
 	"LocalASN":           "Local ASN number",
-	"RemoteIPAddress":    "IP address of remote peer",
+	"RemoteIPAddress":    "IPv4 or IPv6 address of remote peer, of the same address family as the local IP address",
 	"RemoteASN":          "Remote ASN number",
 	"AuthenticationKey":  "Shared key used for BGP peer authentication",
+	"EBGPMultihop":       "Maximum number of hops to a remote eBGP peer which is not directly connected",
+	"KeepaliveTimer":     "Interval in seconds between keepalive messages sent to the remote peer",
+	"HoldTimer":          "Time in seconds without messages from the remote peer after which the session is closed, at least three times the keepalive timer",
+	"PrefixLimit":        "Maximum number of prefixes accepted from the remote peer",
+	"BFDEnabled":         "Boolean value that determines if Bidirectional Forwarding Detection is used to detect a failed peer",
+	"BFDInterval":        "Interval in milliseconds between BFD control packets",
+	"BFDMultiplier":      "Number of missed BFD control packets after which the peer is considered down",
 	"State":              "BGP peer state",
 	"ProvisioningStatus": "BGP peering configuration provisioning status",
+	"ReceivedPrefixes":   "Number of prefixes received from the remote peer",
+	"AdvertisedPrefixes": "Number of prefixes advertised to the remote peer",
 }
 
 func resourceNetworkBGP() *schema.Resource {
@@ -49,9 +68,11 @@ func resourceNetworkBGP() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Schema: createNetworkBGPResourceSchema(),
+		Schema:        createNetworkBGPResourceSchema(),
+		CustomizeDiff: checkNetworkBGPOptions,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Description: "Resource allows creation and management of Equinix Network Edge BGP peering configurations",
 	}
@@ -91,7 +112,7 @@ func createNetworkBGPResourceSchema() map[string]*schema.Schema {
 		networkBGPSchemaNames["RemoteIPAddress"]: {
 			Type:         schema.TypeString,
 			Required:     true,
-			ValidateFunc: validation.IsIPv4Address,
+			ValidateFunc: validation.IsIPAddress,
 			Description:  networkBGPDescriptions["RemoteIPAddress"],
 		},
 		networkBGPSchemaNames["RemoteASN"]: {
@@ -107,6 +128,54 @@ func createNetworkBGPResourceSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringLenBetween(6, 60),
 			Description:  networkBGPDescriptions["AuthenticationKey"],
 		},
+		networkBGPSchemaNames["EBGPMultihop"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(2, 255),
+			Description:  networkBGPDescriptions["EBGPMultihop"],
+		},
+		networkBGPSchemaNames["KeepaliveTimer"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 21845),
+			Description:  networkBGPDescriptions["KeepaliveTimer"],
+		},
+		networkBGPSchemaNames["HoldTimer"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(3, 65535),
+			Description:  networkBGPDescriptions["HoldTimer"],
+		},
+		networkBGPSchemaNames["PrefixLimit"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  networkBGPDescriptions["PrefixLimit"],
+		},
+		networkBGPSchemaNames["BFDEnabled"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: networkBGPDescriptions["BFDEnabled"],
+		},
+		networkBGPSchemaNames["BFDInterval"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(50, 9999),
+			Description:  networkBGPDescriptions["BFDInterval"],
+		},
+		networkBGPSchemaNames["BFDMultiplier"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(3, 50),
+			Description:  networkBGPDescriptions["BFDMultiplier"],
+		},
 		networkBGPSchemaNames["State"]: {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -128,7 +197,7 @@ func resourceNetworkBGPCreate(ctx context.Context, d *schema.ResourceData, m int
 	existingBGP, err := client.GetBGPConfigurationForConnection(ne.StringValue(bgp.ConnectionUUID))
 	if err == nil {
 		bgp.UUID = existingBGP.UUID
-		if updateErr := createNetworkBGPUpdateRequest(client.NewBGPConfigurationUpdateRequest, &bgp); updateErr != nil {
+		if updateErr := createNetworkBGPUpdateRequest(client.NewBGPConfigurationUpdateRequest, &bgp).Execute(); updateErr != nil {
 			return diag.Errorf("failed to update BGP configuration '%s': %s", ne.StringValue(existingBGP.UUID), updateErr)
 		}
 		d.SetId(ne.StringValue(bgp.UUID))
@@ -146,6 +215,15 @@ func resourceNetworkBGPCreate(ctx context.Context, d *schema.ResourceData, m int
 	if _, err := createBGPConfigStatusProvisioningWaitConfiguration(client.GetBGPConfiguration, d.Id(), 2*time.Second, d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
 		return diag.Errorf("error waiting for BGP configuration (%s) to be created: %s", d.Id(), err)
 	}
+	if options := createNetworkBGPOptions(d); options.isSet() {
+		bgp.UUID = ne.String(d.Id())
+		if err := updateNetworkBGPOptions(client, bgp, options); err != nil {
+			return diag.Errorf("failed to set options of BGP configuration '%s': %s", d.Id(), err)
+		}
+		if _, err := createBGPConfigStatusProvisioningWaitConfiguration(client.GetBGPConfiguration, d.Id(), 2*time.Second, d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("error waiting for BGP configuration (%s) to be created: %s", d.Id(), err)
+		}
+	}
 	diags = append(diags, resourceNetworkBGPRead(ctx, d, m)...)
 	return diags
 }
@@ -161,6 +239,13 @@ func resourceNetworkBGPRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err := updateNetworkBGPResource(bgp, d); err != nil {
 		return diag.FromErr(err)
 	}
+	details, err := getNetworkBGPDetails(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateNetworkBGPOptionsResource(details.Options, d); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -169,8 +254,27 @@ func resourceNetworkBGPUpdate(ctx context.Context, d *schema.ResourceData, m int
 	m.(*Config).addModuleToNEUserAgent(&client, d)
 	var diags diag.Diagnostics
 	bgpConfig := createNetworkBGPConfiguration(d)
-	if err := createNetworkBGPUpdateRequest(client.NewBGPConfigurationUpdateRequest, &bgpConfig).Execute(); err != nil {
-		return diag.FromErr(err)
+	bgpConfig.UUID = ne.String(d.Id())
+	configChanged := d.HasChanges(networkBGPSchemaNames["LocalIPAddress"], networkBGPSchemaNames["LocalASN"],
+		networkBGPSchemaNames["RemoteIPAddress"], networkBGPSchemaNames["RemoteASN"], networkBGPSchemaNames["AuthenticationKey"])
+	if configChanged {
+		if err := createNetworkBGPUpdateRequest(client.NewBGPConfigurationUpdateRequest, &bgpConfig).Execute(); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	options := createNetworkBGPOptions(d)
+	if d.HasChanges(networkBGPOptionsSchemaNames()...) || (configChanged && options.isSet()) {
+		if configChanged {
+			if _, err := createBGPConfigStatusProvisioningWaitConfiguration(client.GetBGPConfiguration, d.Id(), 2*time.Second, d.Timeout(schema.TimeoutUpdate)).WaitForStateContext(ctx); err != nil {
+				return diag.Errorf("error waiting for BGP configuration (%s) to be updated: %s", d.Id(), err)
+			}
+		}
+		if err := updateNetworkBGPOptions(client, bgpConfig, options); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if _, err := createBGPConfigStatusProvisioningWaitConfiguration(client.GetBGPConfiguration, d.Id(), 2*time.Second, d.Timeout(schema.TimeoutUpdate)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for BGP configuration (%s) to be updated: %s", d.Id(), err)
 	}
 	diags = append(diags, resourceNetworkBGPRead(ctx, d, m)...)
 	return diags
@@ -208,6 +312,31 @@ func createNetworkBGPConfiguration(d *schema.ResourceData) ne.BGPConfiguration {
 	return bgp
 }
 
+func createNetworkBGPOptions(d *schema.ResourceData) networkBGPOptions {
+	options := networkBGPOptions{
+		BFDEnabled: ne.Bool(d.Get(networkBGPSchemaNames["BFDEnabled"]).(bool)),
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["EBGPMultihop"]); ok {
+		options.EBGPMultihop = ne.Int(v.(int))
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["KeepaliveTimer"]); ok {
+		options.KeepaliveTimer = ne.Int(v.(int))
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["HoldTimer"]); ok {
+		options.HoldTimer = ne.Int(v.(int))
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["PrefixLimit"]); ok {
+		options.PrefixLimit = ne.Int(v.(int))
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["BFDInterval"]); ok {
+		options.BFDInterval = ne.Int(v.(int))
+	}
+	if v, ok := d.GetOk(networkBGPSchemaNames["BFDMultiplier"]); ok {
+		options.BFDMultiplier = ne.Int(v.(int))
+	}
+	return options
+}
+
 func updateNetworkBGPResource(bgp *ne.BGPConfiguration, d *schema.ResourceData) error {
 	if err := d.Set(networkBGPSchemaNames["UUID"], bgp.UUID); err != nil {
 		return fmt.Errorf("error reading UUID: %s", err)
@@ -242,6 +371,63 @@ func updateNetworkBGPResource(bgp *ne.BGPConfiguration, d *schema.ResourceData)
 	return nil
 }
 
+func networkBGPOptionsSchemaNames() []string {
+	return []string{
+		networkBGPSchemaNames["EBGPMultihop"], networkBGPSchemaNames["KeepaliveTimer"], networkBGPSchemaNames["HoldTimer"],
+		networkBGPSchemaNames["PrefixLimit"], networkBGPSchemaNames["BFDEnabled"], networkBGPSchemaNames["BFDInterval"],
+		networkBGPSchemaNames["BFDMultiplier"],
+	}
+}
+
+func updateNetworkBGPOptionsResource(options networkBGPOptions, d *schema.ResourceData) error {
+	if err := d.Set(networkBGPSchemaNames["EBGPMultihop"], options.EBGPMultihop); err != nil {
+		return fmt.Errorf("error reading EBGPMultihop: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["KeepaliveTimer"], options.KeepaliveTimer); err != nil {
+		return fmt.Errorf("error reading KeepaliveTimer: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["HoldTimer"], options.HoldTimer); err != nil {
+		return fmt.Errorf("error reading HoldTimer: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["PrefixLimit"], options.PrefixLimit); err != nil {
+		return fmt.Errorf("error reading PrefixLimit: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["BFDEnabled"], ne.BoolValue(options.BFDEnabled)); err != nil {
+		return fmt.Errorf("error reading BFDEnabled: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["BFDInterval"], options.BFDInterval); err != nil {
+		return fmt.Errorf("error reading BFDInterval: %s", err)
+	}
+	if err := d.Set(networkBGPSchemaNames["BFDMultiplier"], options.BFDMultiplier); err != nil {
+		return fmt.Errorf("error reading BFDMultiplier: %s", err)
+	}
+	return nil
+}
+
+// checkNetworkBGPOptions checks that both peers use the same address family,
+// that the hold timer is at least three times the keepalive timer and that
+// BFD settings are only given when BFD is enabled.
+func checkNetworkBGPOptions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	localIP, remoteIP := d.Get(networkBGPSchemaNames["LocalIPAddress"]).(string), d.Get(networkBGPSchemaNames["RemoteIPAddress"]).(string)
+	if localIP != "" && remoteIP != "" {
+		if err := checkNetworkBGPAddressFamily(localIP, remoteIP); err != nil {
+			return err
+		}
+	}
+	keepalive, hold := d.Get(networkBGPSchemaNames["KeepaliveTimer"]).(int), d.Get(networkBGPSchemaNames["HoldTimer"]).(int)
+	if keepalive > 0 && hold > 0 && hold < 3*keepalive {
+		return fmt.Errorf("%s %d has to be at least three times %s %d", networkBGPSchemaNames["HoldTimer"], hold, networkBGPSchemaNames["KeepaliveTimer"], keepalive)
+	}
+	if !d.Get(networkBGPSchemaNames["BFDEnabled"]).(bool) {
+		for _, key := range []string{networkBGPSchemaNames["BFDInterval"], networkBGPSchemaNames["BFDMultiplier"]} {
+			if d.HasChange(key) && d.Get(key).(int) > 0 {
+				return fmt.Errorf("%s can be set only when %s is true", key, networkBGPSchemaNames["BFDEnabled"])
+			}
+		}
+	}
+	return nil
+}
+
 type bgpUpdateRequest func(uuid string) ne.BGPUpdateRequest
 
 func createNetworkBGPUpdateRequest(requestFunc bgpUpdateRequest, bgp *ne.BGPConfiguration) ne.BGPUpdateRequest {
@@ -253,6 +439,21 @@ func createNetworkBGPUpdateRequest(requestFunc bgpUpdateRequest, bgp *ne.BGPConf
 		WithAuthenticationKey(ne.StringValue(bgp.AuthenticationKey))
 }
 
+func checkNetworkBGPAddressFamily(localIPAddress, remoteIPAddress string) error {
+	localIP, _, err := net.ParseCIDR(localIPAddress)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %s", networkBGPSchemaNames["LocalIPAddress"], localIPAddress, err)
+	}
+	remoteIP := net.ParseIP(remoteIPAddress)
+	if remoteIP == nil {
+		return fmt.Errorf("invalid %s %q", networkBGPSchemaNames["RemoteIPAddress"], remoteIPAddress)
+	}
+	if (localIP.To4() == nil) != (remoteIP.To4() == nil) {
+		return fmt.Errorf("%s %q and %s %q have to be of the same address family", networkBGPSchemaNames["LocalIPAddress"], localIPAddress, networkBGPSchemaNames["RemoteIPAddress"], remoteIPAddress)
+	}
+	return nil
+}
+
 type getBGPConfig func(uuid string) (*ne.BGPConfiguration, error)
 
 func createBGPConfigStatusProvisioningWaitConfiguration(fetchFunc getBGPConfig, id string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
@@ -66,6 +66,93 @@ func TestNetworkBGP_updateResourceData(t *testing.T) {
 	assert.Equal(t, ne.StringValue(input.ProvisioningStatus), d.Get(networkBGPSchemaNames["ProvisioningStatus"]), "ProvisioningStatus matches")
 }
 
+func TestNetworkBGP_createOptionsFromResourceData(t *testing.T) {
+	// given
+	expected := networkBGPOptions{
+		EBGPMultihop:   ne.Int(2),
+		KeepaliveTimer: ne.Int(10),
+		HoldTimer:      ne.Int(30),
+		PrefixLimit:    ne.Int(1000),
+		BFDEnabled:     ne.Bool(true),
+		BFDInterval:    ne.Int(300),
+		BFDMultiplier:  ne.Int(3),
+	}
+	rawData := map[string]interface{}{
+		networkBGPSchemaNames["EBGPMultihop"]:   ne.IntValue(expected.EBGPMultihop),
+		networkBGPSchemaNames["KeepaliveTimer"]: ne.IntValue(expected.KeepaliveTimer),
+		networkBGPSchemaNames["HoldTimer"]:      ne.IntValue(expected.HoldTimer),
+		networkBGPSchemaNames["PrefixLimit"]:    ne.IntValue(expected.PrefixLimit),
+		networkBGPSchemaNames["BFDEnabled"]:     ne.BoolValue(expected.BFDEnabled),
+		networkBGPSchemaNames["BFDInterval"]:    ne.IntValue(expected.BFDInterval),
+		networkBGPSchemaNames["BFDMultiplier"]:  ne.IntValue(expected.BFDMultiplier),
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkBGPResourceSchema(), rawData)
+	// when
+	result := createNetworkBGPOptions(d)
+	// then
+	assert.Equal(t, expected, result, "Created BGP options match expected result")
+}
+
+func TestNetworkBGP_updateOptionsResourceData(t *testing.T) {
+	// given
+	input := networkBGPOptions{
+		KeepaliveTimer: ne.Int(60),
+		HoldTimer:      ne.Int(180),
+		BFDEnabled:     ne.Bool(false),
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkBGPResourceSchema(), make(map[string]interface{}))
+	// when
+	err := updateNetworkBGPOptionsResource(input, d)
+	// then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, 60, d.Get(networkBGPSchemaNames["KeepaliveTimer"]), "KeepaliveTimer matches")
+	assert.Equal(t, 180, d.Get(networkBGPSchemaNames["HoldTimer"]), "HoldTimer matches")
+	assert.Equal(t, false, d.Get(networkBGPSchemaNames["BFDEnabled"]), "BFDEnabled matches")
+	assert.Equal(t, 0, d.Get(networkBGPSchemaNames["PrefixLimit"]), "PrefixLimit is not set")
+}
+
+func TestNetworkBGP_checkAddressFamily(t *testing.T) {
+	// when
+	ipv4Err := checkNetworkBGPAddressFamily("1.1.1.1/30", "1.1.1.2")
+	ipv6Err := checkNetworkBGPAddressFamily("2001:db8::1/126", "2001:db8::2")
+	mixedErr := checkNetworkBGPAddressFamily("1.1.1.1/30", "2001:db8::2")
+	// then
+	assert.Nil(t, ipv4Err, "IPv4 peering is accepted")
+	assert.Nil(t, ipv6Err, "IPv6 peering is accepted")
+	assert.ErrorContains(t, mixedErr, "have to be of the same address family")
+}
+
+func TestNetworkBGP_mapOptionsToModel(t *testing.T) {
+	// given
+	bgp := ne.BGPConfiguration{
+		UUID:            ne.String("0cb9759d-58ab-44e6-9c10-6a3cfd18cefb"),
+		ConnectionUUID:  ne.String("6ca8d0df-c71a-4475-a835-53c2df1e6667"),
+		LocalIPAddress:  ne.String("2001:db8::1/126"),
+		LocalASN:        ne.Int(15344),
+		RemoteIPAddress: ne.String("2001:db8::2"),
+		RemoteASN:       ne.Int(60421),
+	}
+	options := networkBGPOptions{
+		HoldTimer:  ne.Int(90),
+		BFDEnabled: ne.Bool(false),
+	}
+	// when
+	model := mapNetworkBGPOptionsToModel(bgp, options)
+	result := mapNetworkBGPDetailsFromModel(model)
+	// then
+	assert.Equal(t, bgp.LocalIPAddress, model.LocalIPAddress, "LocalIPAddress is sent")
+	assert.Equal(t, bgp.RemoteIPAddress, model.RemoteIPAddress, "RemoteIPAddress is sent")
+	assert.Nil(t, model.KeepaliveTimer, "KeepaliveTimer which is not set is not sent")
+	assert.Equal(t, options, result.Options, "Options match")
+}
+
+func TestNetworkBGP_optionsIsSet(t *testing.T) {
+	assert.False(t, networkBGPOptions{}.isSet(), "Empty options are not set")
+	assert.False(t, networkBGPOptions{BFDEnabled: ne.Bool(false)}.isSet(), "Disabled BFD is not an option")
+	assert.True(t, networkBGPOptions{BFDEnabled: ne.Bool(true)}.isSet(), "Enabled BFD is an option")
+	assert.True(t, networkBGPOptions{PrefixLimit: ne.Int(100)}.isSet(), "Prefix limit is an option")
+}
+
 type mockedBGPUpdateRequest struct {
 	uuid string
 	data map[string]interface{}
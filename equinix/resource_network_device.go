@@ -11,7 +11,9 @@ import (
 
 	"github.com/equinix/ne-go"
 	"github.com/equinix/rest-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -36,6 +38,7 @@ var neDeviceSchemaNames = map[string]string{
 	"LicenseFileID":       "license_file_id",
 	"CloudInitFileID":     "cloud_init_file_id",
 	"LicenseStatus":       "license_status",
+	"LicenseExpiration":   "license_expiration_date",
 	"ACLTemplateUUID":     "acl_template_id",
 	"MgmtAclTemplateUuid": "mgmt_acl_template_uuid",
 	"SSHIPAddress":        "ssh_ip_address",
@@ -54,6 +57,7 @@ var neDeviceSchemaNames = map[string]string{
 	"WanInterfaceId":      "wan_interface_id",
 	"Interfaces":          "interface",
 	"VendorConfiguration": "vendor_configuration",
+	"VendorSecrets":       "sensitive_vendor_configuration",
 	"UserPublicKey":       "ssh_key",
 	"ASN":                 "asn",
 	"ZoneCode":            "zone_code",
@@ -82,6 +86,7 @@ var neDeviceDescriptions = map[string]string{
 	"LicenseFileID":       "Unique identifier of applied license file",
 	"CloudInitFileID":     "Unique identifier of applied cloud init file",
 	"LicenseStatus":       "Device license registration status",
+	"LicenseExpiration":   "Expiration date of the device license",
 	"ACLTemplateUUID":     "Unique identifier of applied ACL template",
 	"MgmtAclTemplateUuid": "Unique identifier of applied MGMT ACL template",
 	"SSHIPAddress":        "IP address of SSH enabled interface on the device",
@@ -99,7 +104,8 @@ var neDeviceDescriptions = map[string]string{
 	"IsSelfManaged":       "Boolean value that determines device management mode: self-managed or subscription (default)",
 	"WanInterfaceId":      "device interface id picked for WAN",
 	"Interfaces":          "List of device interfaces",
-	"VendorConfiguration": "Map of vendor specific configuration parameters for a device (controller1, activationKey, managementType, siteId, systemIpAddress)",
+	"VendorConfiguration": "Map of vendor specific configuration parameters for a device (controller1, managementType, siteId, systemIpAddress). Sensitive parameters are set in sensitive_vendor_configuration",
+	"VendorSecrets":       "Map of vendor specific configuration parameters for a device, like activationKey or adminPassword, which are redacted in the plan output",
 	"UserPublicKey":       "Definition of SSH key that will be provisioned on a device",
 	"ASN":                 "Autonomous system number",
 	"ZoneCode":            "Device location zone code",
@@ -201,6 +207,12 @@ func resourceNetworkDevice() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: createNetworkDeviceSchema(),
+		CustomizeDiff: customdiff.Sequence(
+			checkNetworkDeviceVersionUpgrade,
+			checkNetworkDeviceResize,
+			checkNetworkDeviceLicenseUpdate,
+			checkNetworkDeviceVendorConfigurationDiff,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -246,6 +258,11 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: neDeviceDescriptions["LicenseStatus"],
 		},
+		neDeviceSchemaNames["LicenseExpiration"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: neDeviceDescriptions["LicenseExpiration"],
+		},
 		neDeviceSchemaNames["MetroCode"]: {
 			Type:         schema.TypeString,
 			Required:     true,
@@ -266,14 +283,12 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 		neDeviceSchemaNames["Throughput"]: {
 			Type:         schema.TypeInt,
 			Optional:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.IntAtLeast(1),
 			Description:  neDeviceDescriptions["Throughput"],
 		},
 		neDeviceSchemaNames["ThroughputUnit"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.StringInSlice([]string{"Mbps", "Gbps"}, false),
 			RequiredWith: []string{neDeviceSchemaNames["Throughput"]},
 			Description:  neDeviceDescriptions["ThroughputUnit"],
@@ -288,14 +303,12 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 		neDeviceSchemaNames["PackageCode"]: {
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  neDeviceDescriptions["PackageCode"],
 		},
 		neDeviceSchemaNames["Version"]: {
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  neDeviceDescriptions["Version"],
 		},
@@ -309,7 +322,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 		neDeviceSchemaNames["LicenseToken"]: {
 			Type:          schema.TypeString,
 			Optional:      true,
-			ForceNew:      true,
 			ValidateFunc:  validation.StringIsNotEmpty,
 			ConflictsWith: []string{neDeviceSchemaNames["LicenseFile"]},
 			Description:   neDeviceDescriptions["LicenseToken"],
@@ -317,7 +329,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 		neDeviceSchemaNames["LicenseFile"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  neDeviceDescriptions["LicenseFile"],
 		},
@@ -325,7 +336,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			Type:          schema.TypeString,
 			Optional:      true,
 			Computed:      true,
-			ForceNew:      true,
 			ValidateFunc:  validation.StringIsNotEmpty,
 			ConflictsWith: []string{neDeviceSchemaNames["LicenseFile"]},
 			Description:   neDeviceDescriptions["LicenseFileID"],
@@ -416,14 +426,12 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			Type:         schema.TypeInt,
 			Optional:     true,
 			Computed:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.IntAtLeast(1),
 			Description:  neDeviceDescriptions["InterfaceCount"],
 		},
 		neDeviceSchemaNames["CoreCount"]: {
 			Type:         schema.TypeInt,
 			Required:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.IntAtLeast(1),
 			Description:  neDeviceDescriptions["CoreCount"],
 		},
@@ -460,6 +468,18 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			},
 			Description: neDeviceDescriptions["VendorConfiguration"],
 		},
+		neDeviceSchemaNames["VendorSecrets"]: {
+			Type:      schema.TypeMap,
+			Optional:  true,
+			Computed:  true,
+			ForceNew:  true,
+			Sensitive: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Description: neDeviceDescriptions["VendorSecrets"],
+		},
 		neDeviceSchemaNames["UserPublicKey"]: {
 			Type:     schema.TypeSet,
 			Optional: true,
@@ -518,6 +538,11 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 						Computed:    true,
 						Description: neDeviceDescriptions["LicenseStatus"],
 					},
+					neDeviceSchemaNames["LicenseExpiration"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: neDeviceDescriptions["LicenseExpiration"],
+					},
 					neDeviceSchemaNames["MetroCode"]: {
 						Type:         schema.TypeString,
 						Required:     true,
@@ -544,7 +569,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 					neDeviceSchemaNames["LicenseToken"]: {
 						Type:          schema.TypeString,
 						Optional:      true,
-						ForceNew:      true,
 						ValidateFunc:  validation.StringIsNotEmpty,
 						ConflictsWith: []string{neDeviceSchemaNames["Secondary"] + ".0." + neDeviceSchemaNames["LicenseFile"]},
 						Description:   neDeviceDescriptions["LicenseToken"],
@@ -552,7 +576,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 					neDeviceSchemaNames["LicenseFile"]: {
 						Type:         schema.TypeString,
 						Optional:     true,
-						ForceNew:     true,
 						ValidateFunc: validation.StringIsNotEmpty,
 						Description:  neDeviceDescriptions["LicenseFile"],
 					},
@@ -560,7 +583,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 						Type:          schema.TypeString,
 						Optional:      true,
 						Computed:      true,
-						ForceNew:      true,
 						ValidateFunc:  validation.StringIsNotEmpty,
 						ConflictsWith: []string{neDeviceSchemaNames["Secondary"] + ".0." + neDeviceSchemaNames["LicenseFile"]},
 						Description:   neDeviceDescriptions["LicenseFileID"],
@@ -653,6 +675,18 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 						},
 						Description: neDeviceDescriptions["VendorConfiguration"],
 					},
+					neDeviceSchemaNames["VendorSecrets"]: {
+						Type:      schema.TypeMap,
+						Optional:  true,
+						Computed:  true,
+						ForceNew:  true,
+						Sensitive: true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						Description: neDeviceDescriptions["VendorSecrets"],
+					},
 					neDeviceSchemaNames["UserPublicKey"]: {
 						Type:     schema.TypeSet,
 						Optional: true,
@@ -949,6 +983,7 @@ func resourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m in
 	if err = updateNetworkDeviceResource(primary, secondary, d); err != nil {
 		return diag.FromErr(err)
 	}
+	diags = append(diags, updateNetworkDeviceLicenseExpiration(networkDeviceLicenseDetailsGetter(client), primary, secondary, d)...)
 	return diags
 }
 
@@ -984,10 +1019,203 @@ func resourceNetworkDeviceUpdate(ctx context.Context, d *schema.ResourceData, m
 			return diag.Errorf("error waiting for network device %q to be updated: %s", d.Get(neDeviceSchemaNames["RedundantUUID"]), err)
 		}
 	}
+	if license := getNetworkDeviceLicenseChange(d, ""); license != nil {
+		license.UUID = ne.String(d.Id())
+		license.MetroCode = ne.String(d.Get(neDeviceSchemaNames["MetroCode"]).(string))
+		if err := updateNetworkDeviceLicense(ctx, client, d.Get(neDeviceSchemaNames["TypeCode"]).(string), license, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk(neDeviceSchemaNames["RedundantUUID"]); ok {
+		secondaryPrefix := neDeviceSchemaNames["Secondary"] + ".0."
+		if license := getNetworkDeviceLicenseChange(d, secondaryPrefix); license != nil {
+			license.UUID = ne.String(v.(string))
+			license.MetroCode = ne.String(d.Get(secondaryPrefix + neDeviceSchemaNames["MetroCode"]).(string))
+			if err := updateNetworkDeviceLicense(ctx, client, d.Get(neDeviceSchemaNames["TypeCode"]).(string), license, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if resizeChanges := getNetworkDeviceResizeChanges(d); len(resizeChanges) > 0 {
+		if err := resizeNetworkDevice(ctx, neDeviceUpgrader(client), client.GetDevice, getNetworkDeviceUpgradeOrder(d), resizeChanges, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange(neDeviceSchemaNames["Version"]) {
+		version := d.Get(neDeviceSchemaNames["Version"]).(string)
+		if err := upgradeNetworkDeviceVersion(ctx, neDeviceUpgrader(client), client.GetDevice, getNetworkDeviceUpgradeOrder(d), version, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	diags = append(diags, resourceNetworkDeviceRead(ctx, d, m)...)
 	return diags
 }
 
+// checkNetworkDeviceVersionUpgrade checks a new version of an existing device
+// against the software versions of its device type.
+func checkNetworkDeviceVersionUpgrade(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !(d.HasChange(neDeviceSchemaNames["Version"]) || d.HasChange(neDeviceSchemaNames["PackageCode"])) {
+		return nil
+	}
+	if !d.NewValueKnown(neDeviceSchemaNames["Version"]) || !d.NewValueKnown(neDeviceSchemaNames["PackageCode"]) {
+		return nil
+	}
+	typeCode := d.Get(neDeviceSchemaNames["TypeCode"]).(string)
+	versions, err := m.(*Config).ne.GetDeviceSoftwareVersions(typeCode)
+	if err != nil {
+		return fmt.Errorf("could not fetch software versions of device type %q: %s", typeCode, err)
+	}
+	return checkNetworkDeviceSoftwareVersion(versions, typeCode, d.Get(neDeviceSchemaNames["PackageCode"]).(string), d.Get(neDeviceSchemaNames["Version"]).(string))
+}
+
+// checkNetworkDeviceResize checks a new core count or package of an existing
+// device against the platforms of its device type. Throughput of a device with
+// bring your own license is set by the license and can't be changed.
+func checkNetworkDeviceResize(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	changes := getNetworkDeviceResizeChanges(d)
+	for key := range changes {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	if v, ok := changes[neDeviceSchemaNames["Throughput"]]; ok {
+		if v.(int) == 0 {
+			return fmt.Errorf("%s of an existing device can't be removed", neDeviceSchemaNames["Throughput"])
+		}
+		if d.Get(neDeviceSchemaNames["IsBYOL"]).(bool) {
+			return fmt.Errorf("%s of a device with bring your own license is set by the license and can't be changed", neDeviceSchemaNames["Throughput"])
+		}
+	}
+	typeCode := d.Get(neDeviceSchemaNames["TypeCode"]).(string)
+	if _, ok := changes[neDeviceSchemaNames["InterfaceCount"]]; ok {
+		if err := checkNetworkDeviceInterfaceCountResize(d, m.(*Config).ne, typeCode); err != nil {
+			return err
+		}
+	}
+	_, coreChanged := changes[neDeviceSchemaNames["CoreCount"]]
+	_, packageChanged := changes[neDeviceSchemaNames["PackageCode"]]
+	if !coreChanged && !packageChanged {
+		return nil
+	}
+	platforms, err := m.(*Config).ne.GetDevicePlatforms(typeCode)
+	if err != nil {
+		return fmt.Errorf("could not fetch platforms of device type %q: %s", typeCode, err)
+	}
+	managementType := ne.DeviceManagementTypeEquinix
+	if d.Get(neDeviceSchemaNames["IsSelfManaged"]).(bool) {
+		managementType = ne.DeviceManagementTypeSelf
+	}
+	licenseMode := ne.DeviceLicenseModeSubscription
+	if d.Get(neDeviceSchemaNames["IsBYOL"]).(bool) {
+		licenseMode = ne.DeviceLicenseModeBYOL
+	}
+	return checkNetworkDevicePlatform(platforms, typeCode, d.Get(neDeviceSchemaNames["CoreCount"]).(int),
+		d.Get(neDeviceSchemaNames["PackageCode"]).(string), managementType, licenseMode)
+}
+
+// checkNetworkDeviceLicenseUpdate marks the license file identifier as
+// computed when a new license file is going to be uploaded.
+func checkNetworkDeviceLicenseUpdate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange(neDeviceSchemaNames["LicenseFile"]) {
+		return nil
+	}
+	if d.Get(neDeviceSchemaNames["LicenseFile"]).(string) == "" {
+		return nil
+	}
+	return d.SetNewComputed(neDeviceSchemaNames["LicenseFileID"])
+}
+
+// checkNetworkDeviceVendorConfigurationDiff checks the configured vendor
+// configuration, with the vendor secrets, of new devices and of devices with
+// changed vendor configuration against the requirements of the device type.
+func checkNetworkDeviceVendorConfigurationDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(neDeviceSchemaNames["TypeCode"]) {
+		return nil
+	}
+	typeCode := d.Get(neDeviceSchemaNames["TypeCode"]).(string)
+	requirements := getNetworkDeviceVendorConfigRequirements(typeCode)
+	config := d.GetRawConfig()
+	if d.Id() == "" || d.HasChanges(neDeviceSchemaNames["VendorConfiguration"], neDeviceSchemaNames["VendorSecrets"]) {
+		if err := checkNetworkDeviceVendorConfigurationValues(requirements, typeCode, config.GetAttr(neDeviceSchemaNames["VendorConfiguration"]), config.GetAttr(neDeviceSchemaNames["VendorSecrets"]), d.Id() == ""); err != nil {
+			return fmt.Errorf("invalid %s: %s", neDeviceSchemaNames["VendorConfiguration"], err)
+		}
+	}
+	secondary := config.GetAttr(neDeviceSchemaNames["Secondary"])
+	if secondary.IsNull() || !secondary.IsKnown() || secondary.LengthInt() == 0 {
+		return nil
+	}
+	secondaryKey := neDeviceSchemaNames["Secondary"] + ".0." + neDeviceSchemaNames["VendorConfiguration"]
+	secondarySecretsKey := neDeviceSchemaNames["Secondary"] + ".0." + neDeviceSchemaNames["VendorSecrets"]
+	if d.Id() == "" || d.HasChanges(secondaryKey, secondarySecretsKey) {
+		secondaryConfig := secondary.Index(cty.NumberIntVal(0))
+		if err := checkNetworkDeviceVendorConfigurationValues(requirements, typeCode, secondaryConfig.GetAttr(neDeviceSchemaNames["VendorConfiguration"]), secondaryConfig.GetAttr(neDeviceSchemaNames["VendorSecrets"]), d.Id() == ""); err != nil {
+			return fmt.Errorf("invalid %s: %s", secondaryKey, err)
+		}
+	}
+	return nil
+}
+
+// checkNetworkDeviceInterfaceCountResize forces a new device when the
+// interface count is lowered, as interfaces can only be added to an existing
+// device, and checks a grown interface count against the device type.
+func checkNetworkDeviceInterfaceCountResize(d *schema.ResourceDiff, client ne.Client, typeCode string) error {
+	key := neDeviceSchemaNames["InterfaceCount"]
+	old, new := d.GetChange(key)
+	if new.(int) == 0 {
+		return nil
+	}
+	if new.(int) < old.(int) {
+		return d.ForceNew(key)
+	}
+	limits, err := getNetworkDeviceTypeInterfaceLimits(client, typeCode)
+	if err != nil {
+		return fmt.Errorf("could not fetch interface limits of device type %q: %s", typeCode, err)
+	}
+	_, isCluster := d.GetOk(neDeviceSchemaNames["ClusterDetails"])
+	return checkNetworkDeviceInterfaceCount(*limits, new.(int), isCluster)
+}
+
+// getNetworkDeviceResizeChanges returns the changed core count, package,
+// throughput and interface count. Throughput is always changed together with
+// its unit.
+func getNetworkDeviceResizeChanges(d resourceDataProvider) map[string]interface{} {
+	changes := getResourceDataChangedKeys([]string{
+		neDeviceSchemaNames["CoreCount"], neDeviceSchemaNames["PackageCode"],
+		neDeviceSchemaNames["Throughput"], neDeviceSchemaNames["ThroughputUnit"],
+		neDeviceSchemaNames["InterfaceCount"],
+	}, d)
+	_, throughputChanged := changes[neDeviceSchemaNames["Throughput"]]
+	_, unitChanged := changes[neDeviceSchemaNames["ThroughputUnit"]]
+	if throughputChanged || unitChanged {
+		changes[neDeviceSchemaNames["Throughput"]] = d.Get(neDeviceSchemaNames["Throughput"])
+		changes[neDeviceSchemaNames["ThroughputUnit"]] = d.Get(neDeviceSchemaNames["ThroughputUnit"])
+	}
+	return changes
+}
+
+// getNetworkDeviceUpgradeOrder returns the devices to upgrade one at a time.
+// The nodes of a cluster are upgraded one by one, and the secondary device of
+// an HA pair is upgraded before the primary device.
+func getNetworkDeviceUpgradeOrder(d *schema.ResourceData) []string {
+	nodes := []string{}
+	for _, node := range []string{"Node1", "Node0"} {
+		key := fmt.Sprintf("%s.0.%s.0.%s", neDeviceSchemaNames["ClusterDetails"], neDeviceClusterSchemaNames[node], neDeviceClusterNodeSchemaNames["UUID"])
+		if v, ok := d.GetOk(key); ok {
+			nodes = append(nodes, v.(string))
+		}
+	}
+	if len(nodes) > 0 {
+		return nodes
+	}
+	if v, ok := d.GetOk(neDeviceSchemaNames["RedundantUUID"]); ok {
+		return []string{v.(string), d.Id()}
+	}
+	return []string{d.Id()}
+}
+
 func resourceNetworkDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).ne
 	m.(*Config).addModuleToNEUserAgent(&client, d)
@@ -1091,9 +1319,7 @@ func createNetworkDevices(d *schema.ResourceData) (*ne.Device, *ne.Device) {
 		primary.CoreCount = ne.Int(v.(int))
 	}
 	primary.IsSelfManaged = ne.Bool(d.Get(neDeviceSchemaNames["IsSelfManaged"]).(bool))
-	if v, ok := d.GetOk(neDeviceSchemaNames["VendorConfiguration"]); ok {
-		primary.VendorConfiguration = expandInterfaceMapToStringMap(v.(map[string]interface{}))
-	}
+	primary.VendorConfiguration = expandNetworkDeviceVendorConfiguration(d.Get(neDeviceSchemaNames["VendorConfiguration"]), d.Get(neDeviceSchemaNames["VendorSecrets"]))
 	if v, ok := d.GetOk(neDeviceSchemaNames["WanInterfaceId"]); ok {
 		primary.WanInterfaceId = ne.String(v.(string))
 	}
@@ -1207,9 +1433,15 @@ func updateNetworkDeviceResource(primary *ne.Device, secondary *ne.Device, d *sc
 	if err := d.Set(neDeviceSchemaNames["Interfaces"], flattenNetworkDeviceInterfaces(primary.Interfaces)); err != nil {
 		return fmt.Errorf("error reading Interfaces: %s", err)
 	}
-	if err := d.Set(neDeviceSchemaNames["VendorConfiguration"], primary.VendorConfiguration); err != nil {
+	vendorRequirements := getNetworkDeviceVendorConfigRequirements(ne.StringValue(primary.TypeCode))
+	vendorConfig, vendorSecrets := splitNetworkDeviceVendorConfiguration(vendorRequirements, primary.VendorConfiguration,
+		d.Get(neDeviceSchemaNames["VendorConfiguration"]), d.Get(neDeviceSchemaNames["VendorSecrets"]))
+	if err := d.Set(neDeviceSchemaNames["VendorConfiguration"], vendorConfig); err != nil {
 		return fmt.Errorf("error reading VendorConfiguration: %s", err)
 	}
+	if err := d.Set(neDeviceSchemaNames["VendorSecrets"], vendorSecrets); err != nil {
+		return fmt.Errorf("error reading VendorSecrets: %s", err)
+	}
 	if err := d.Set(neDeviceSchemaNames["UserPublicKey"], flattenNetworkDeviceUserKeys([]*ne.DeviceUserPublicKey{primary.UserPublicKey})); err != nil {
 		return fmt.Errorf("error reading VendorConfiguration: %s", err)
 	}
@@ -1220,13 +1452,21 @@ func updateNetworkDeviceResource(primary *ne.Device, secondary *ne.Device, d *sc
 		return fmt.Errorf("error reading ZoneCode: %s", err)
 	}
 	if secondary != nil {
+		var existingVendorConfig, existingVendorSecrets interface{}
 		if v, ok := d.GetOk(neDeviceSchemaNames["Secondary"]); ok {
 			secondaryFromSchema := expandNetworkDeviceSecondary(v.([]interface{}))
 			secondary.LicenseFile = secondaryFromSchema.LicenseFile
 			secondary.LicenseToken = secondaryFromSchema.LicenseToken
 			secondary.CloudInitFileID = secondaryFromSchema.CloudInitFileID
+			existingSecondary := v.([]interface{})[0].(map[string]interface{})
+			existingVendorConfig = existingSecondary[neDeviceSchemaNames["VendorConfiguration"]]
+			existingVendorSecrets = existingSecondary[neDeviceSchemaNames["VendorSecrets"]]
 		}
-		if err := d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(secondary)); err != nil {
+		secondaryTransformed := flattenNetworkDeviceSecondary(secondary).([]interface{})
+		secondaryVendorConfig, secondaryVendorSecrets := splitNetworkDeviceVendorConfiguration(vendorRequirements, secondary.VendorConfiguration, existingVendorConfig, existingVendorSecrets)
+		secondaryTransformed[0].(map[string]interface{})[neDeviceSchemaNames["VendorConfiguration"]] = secondaryVendorConfig
+		secondaryTransformed[0].(map[string]interface{})[neDeviceSchemaNames["VendorSecrets"]] = secondaryVendorSecrets
+		if err := d.Set(neDeviceSchemaNames["Secondary"], secondaryTransformed); err != nil {
 			return fmt.Errorf("error reading Secondary: %s", err)
 		}
 	}
@@ -1324,9 +1564,7 @@ func expandNetworkDeviceSecondary(devices []interface{}) *ne.Device {
 	if v, ok := device[neDeviceSchemaNames["WanInterfaceId"]]; ok && !isEmpty(v) {
 		transformed.WanInterfaceId = ne.String(v.(string))
 	}
-	if v, ok := device[neDeviceSchemaNames["VendorConfiguration"]]; ok {
-		transformed.VendorConfiguration = expandInterfaceMapToStringMap(v.(map[string]interface{}))
-	}
+	transformed.VendorConfiguration = expandNetworkDeviceVendorConfiguration(device[neDeviceSchemaNames["VendorConfiguration"]], device[neDeviceSchemaNames["VendorSecrets"]])
 	if v, ok := device[neDeviceSchemaNames["UserPublicKey"]]; ok {
 		userKeys := expandNetworkDeviceUserKeys(v.(*schema.Set))
 		if len(userKeys) > 0 {
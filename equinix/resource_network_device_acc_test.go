@@ -118,6 +118,8 @@ func TestAccNetworkDevice_CSR1000V_HA_Managed_Sub(t *testing.T) {
 	contextWithACLs["acl-secondary_resourceName"] = "acl-sec"
 	contextWithACLs["acl-secondary_name"] = fmt.Sprintf("%s-%s", tstResourcePrefix, randString(6))
 	contextWithACLs["acl-secondary_description"] = randString(50)
+	contextWithUpgrade := copyMap(contextWithACLs)
+	contextWithUpgrade["device-core_count"] = 4
 	deviceResourceName := fmt.Sprintf("equinix_network_device.%s", context["device-resourceName"].(string))
 	userResourceName := fmt.Sprintf("equinix_network_ssh_user.%s", context["user-resourceName"].(string))
 	priACLResourceName := fmt.Sprintf("equinix_network_acl_template.%s", contextWithACLs["acl-resourceName"].(string))
@@ -164,6 +166,20 @@ func TestAccNetworkDevice_CSR1000V_HA_Managed_Sub(t *testing.T) {
 					testAccNeDeviceACL(secACLResourceName, &secondary),
 				),
 			},
+			{
+				Config: newTestAccConfig(contextWithUpgrade).withDevice().
+					withSSHUser().withACL().build(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNeDeviceNotReplaced(deviceResourceName, &primary),
+					testAccNeDeviceExists(deviceResourceName, &primary),
+					testAccNeDeviceAttributes(&primary, contextWithUpgrade),
+					testAccNeDeviceStatusAttributes(&primary, ne.DeviceStateProvisioned, ne.DeviceLicenseStateRegistered),
+					resource.TestCheckResourceAttrPair(deviceResourceName, "secondary_device.0.uuid", deviceResourceName, "redundant_id"),
+					testAccNeDeviceSecondaryExists(&primary, &secondary),
+					testAccNeDeviceSecondaryAttributes(&secondary, contextWithUpgrade),
+					testAccNeDeviceStatusAttributes(&secondary, ne.DeviceStateProvisioned, ne.DeviceLicenseStateRegistered),
+				),
+			},
 		},
 	})
 }
@@ -916,6 +932,19 @@ func testAccNeDeviceExists(resourceName string, device *ne.Device) resource.Test
 	}
 }
 
+func testAccNeDeviceNotReplaced(resourceName string, device *ne.Device) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		if rs.Primary.ID != ne.StringValue(device.UUID) {
+			return fmt.Errorf("network device was replaced: ID changed from %q to %q", ne.StringValue(device.UUID), rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
 func testAccNeDeviceSecondaryExists(primary, secondary *ne.Device) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if ne.StringValue(primary.RedundantUUID) == "" {
@@ -1547,6 +1576,7 @@ resource "equinix_network_acl_template" "%{acl-resourceName}" {
   name          = "%{acl-name}"
   description   = "%{acl-description}"
   inbound_rule {
+    sequence_number = 1
     subnet   = "10.0.0.0/24"
     protocol = "IP"
     src_port = "any"
@@ -1560,6 +1590,7 @@ resource "equinix_network_acl_template" "%{mgmtAcl-resourceName}" {
   name          = "%{mgmtAcl-name}"
   description   = "%{mgmtAcl-description}"
   inbound_rule {
+    sequence_number = 1
     subnet   = "11.0.0.0/24"
     protocol = "IP"
     src_port = "any"
@@ -1573,6 +1604,7 @@ resource "equinix_network_acl_template" "%{acl-secondary_resourceName}" {
   name          = "%{acl-secondary_name}"
   description   = "%{acl-secondary_description}"
   inbound_rule {
+    sequence_number = 1
     subnet   = "192.0.0.0/24"
     protocol = "IP"
     src_port = "any"
@@ -1586,6 +1618,7 @@ resource "equinix_network_acl_template" "%{mgmtAcl-secondary_resourceName}" {
   name          = "%{mgmtAcl-secondary_name}"
   description   = "%{mgmtAcl-secondary_description}"
   inbound_rule {
+    sequence_number = 1
     subnet   = "193.0.0.0/24"
     protocol = "IP"
     src_port = "any"
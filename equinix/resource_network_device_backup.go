@@ -0,0 +1,213 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/equinix/rest-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var networkDeviceBackupSchemaNames = map[string]string{
+	"UUID":            "uuid",
+	"Name":            "name",
+	"DeviceUUID":      "device_id",
+	"Version":         "version",
+	"Type":            "type",
+	"Status":          "status",
+	"DownloadURL":     "download_url",
+	"DeleteAllowed":   "delete_allowed",
+	"CreatedBy":       "created_by",
+	"LastUpdatedDate": "last_updated_date",
+}
+
+var networkDeviceBackupDescriptions = map[string]string{
+	"UUID":            "Unique identifier of the backup",
+	"Name":            "Backup name",
+	"DeviceUUID":      "Unique identifier of the network device whose configuration is backed up",
+	"Version":         "Software version of the device when the backup was taken",
+	"Type":            "Device type of the backed up device",
+	"Status":          "Backup status",
+	"DownloadURL":     "URL to download the backed up device configuration",
+	"DeleteAllowed":   "Boolean value that determines if the backup can be deleted",
+	"CreatedBy":       "User that created the backup",
+	"LastUpdatedDate": "Date when the backup was last updated",
+}
+
+func resourceNetworkDeviceBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkDeviceBackupCreate,
+		ReadContext:   resourceNetworkDeviceBackupRead,
+		DeleteContext: resourceNetworkDeviceBackupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: createNetworkDeviceBackupSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Description: "Resource allows creation and management of Equinix Network Edge device configuration backups",
+	}
+}
+
+func createNetworkDeviceBackupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		networkDeviceBackupSchemaNames["UUID"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["UUID"],
+		},
+		networkDeviceBackupSchemaNames["Name"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, 50),
+			Description:  networkDeviceBackupDescriptions["Name"],
+		},
+		networkDeviceBackupSchemaNames["DeviceUUID"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  networkDeviceBackupDescriptions["DeviceUUID"],
+		},
+		networkDeviceBackupSchemaNames["Version"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["Version"],
+		},
+		networkDeviceBackupSchemaNames["Type"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["Type"],
+		},
+		networkDeviceBackupSchemaNames["Status"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["Status"],
+		},
+		networkDeviceBackupSchemaNames["DownloadURL"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["DownloadURL"],
+		},
+		networkDeviceBackupSchemaNames["DeleteAllowed"]: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["DeleteAllowed"],
+		},
+		networkDeviceBackupSchemaNames["CreatedBy"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["CreatedBy"],
+		},
+		networkDeviceBackupSchemaNames["LastUpdatedDate"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupDescriptions["LastUpdatedDate"],
+		},
+	}
+}
+
+func resourceNetworkDeviceBackupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	m.(*Config).addModuleToNEUserAgent(&client, d)
+	var diags diag.Diagnostics
+	uuid, err := createNetworkDeviceBackup(client, d.Get(networkDeviceBackupSchemaNames["DeviceUUID"]).(string), d.Get(networkDeviceBackupSchemaNames["Name"]).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ne.StringValue(uuid))
+	if _, err := createNetworkDeviceBackupStatusWaitConfiguration(networkDeviceBackupGetter(client), d.Id(), 5*time.Second, d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for network device backup %q to be completed: %s", d.Id(), err)
+	}
+	diags = append(diags, resourceNetworkDeviceBackupRead(ctx, d, m)...)
+	return diags
+}
+
+func resourceNetworkDeviceBackupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	m.(*Config).addModuleToNEUserAgent(&client, d)
+	var diags diag.Diagnostics
+	backup, err := networkDeviceBackupGetter(client)(d.Id())
+	if err != nil {
+		if restErr, ok := err.(rest.Error); ok {
+			if restErr.HTTPCode == http.StatusNotFound {
+				d.SetId("")
+				return diags
+			}
+		}
+		return diag.FromErr(err)
+	}
+	if err := updateNetworkDeviceBackupResource(backup, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceNetworkDeviceBackupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	m.(*Config).addModuleToNEUserAgent(&client, d)
+	var diags diag.Diagnostics
+	if err := deleteNetworkDeviceBackup(client, d.Id()); err != nil {
+		if restErr, ok := err.(rest.Error); ok {
+			if restErr.HTTPCode == http.StatusNotFound {
+				return diags
+			}
+		}
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func updateNetworkDeviceBackupResource(backup *networkDeviceBackup, d *schema.ResourceData) error {
+	attributes := flattenNetworkDeviceBackup(*backup)
+	for key, value := range attributes {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error reading %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+func flattenNetworkDeviceBackup(backup networkDeviceBackup) map[string]interface{} {
+	return map[string]interface{}{
+		networkDeviceBackupSchemaNames["UUID"]:            backup.UUID,
+		networkDeviceBackupSchemaNames["Name"]:            backup.Name,
+		networkDeviceBackupSchemaNames["DeviceUUID"]:      backup.DeviceUUID,
+		networkDeviceBackupSchemaNames["Version"]:         backup.Version,
+		networkDeviceBackupSchemaNames["Type"]:            backup.Type,
+		networkDeviceBackupSchemaNames["Status"]:          backup.Status,
+		networkDeviceBackupSchemaNames["DownloadURL"]:     backup.DownloadURL,
+		networkDeviceBackupSchemaNames["DeleteAllowed"]:   ne.BoolValue(backup.DeleteAllowed),
+		networkDeviceBackupSchemaNames["CreatedBy"]:       backup.CreatedBy,
+		networkDeviceBackupSchemaNames["LastUpdatedDate"]: backup.LastUpdatedDate,
+	}
+}
+
+func createNetworkDeviceBackupStatusWaitConfiguration(fetchFunc getDeviceBackup, id string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending: []string{
+			neDeviceBackupStatusPending,
+		},
+		Target: []string{
+			neDeviceBackupStatusCompleted,
+		},
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: delay,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := fetchFunc(id)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp, ne.StringValue(resp.Status), nil
+		},
+	}
+}
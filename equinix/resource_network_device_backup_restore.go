@@ -0,0 +1,128 @@
+package equinix
+
+import (
+	"context"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var networkDeviceBackupRestoreSchemaNames = map[string]string{
+	"DeviceUUID":   "device_id",
+	"BackupUUID":   "backup_id",
+	"Triggers":     "triggers",
+	"DeviceStatus": "device_status",
+}
+
+var networkDeviceBackupRestoreDescriptions = map[string]string{
+	"DeviceUUID":   "Unique identifier of the network device to restore",
+	"BackupUUID":   "Unique identifier of the backup to restore",
+	"Triggers":     "Arbitrary map of values that, when changed, restores the backup again",
+	"DeviceStatus": "Status of the network device after the restore",
+}
+
+func resourceNetworkDeviceBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkDeviceBackupRestoreCreate,
+		ReadContext:   resourceNetworkDeviceBackupRestoreRead,
+		DeleteContext: resourceNetworkDeviceBackupRestoreDelete,
+		Schema:        createNetworkDeviceBackupRestoreSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Description: "Resource allows restoring Equinix Network Edge device configuration from a backup",
+	}
+}
+
+func createNetworkDeviceBackupRestoreSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		networkDeviceBackupRestoreSchemaNames["DeviceUUID"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  networkDeviceBackupRestoreDescriptions["DeviceUUID"],
+		},
+		networkDeviceBackupRestoreSchemaNames["BackupUUID"]: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  networkDeviceBackupRestoreDescriptions["BackupUUID"],
+		},
+		networkDeviceBackupRestoreSchemaNames["Triggers"]: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: networkDeviceBackupRestoreDescriptions["Triggers"],
+		},
+		networkDeviceBackupRestoreSchemaNames["DeviceStatus"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceBackupRestoreDescriptions["DeviceStatus"],
+		},
+	}
+}
+
+func resourceNetworkDeviceBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).ne
+	m.(*Config).addModuleToNEUserAgent(&client, d)
+	var diags diag.Diagnostics
+	deviceID := d.Get(networkDeviceBackupRestoreSchemaNames["DeviceUUID"]).(string)
+	backupID := d.Get(networkDeviceBackupRestoreSchemaNames["BackupUUID"]).(string)
+	backup, err := networkDeviceBackupGetter(client)(backupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ne.StringValue(backup.DeviceUUID) != "" && ne.StringValue(backup.DeviceUUID) != deviceID {
+		return diag.Errorf("backup %q was taken from network device %q, not from %q", backupID, ne.StringValue(backup.DeviceUUID), deviceID)
+	}
+	if ne.StringValue(backup.Status) != neDeviceBackupStatusCompleted {
+		return diag.Errorf("backup %q can't be restored in status %q", backupID, ne.StringValue(backup.Status))
+	}
+	if err := restoreNetworkDeviceBackup(client, backupID); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(backupID + ":" + time.Now().UTC().Format(time.RFC3339))
+	resp, err := createNetworkDeviceRestoreWaitConfiguration(client.GetDevice, deviceID, 30*time.Second, 10*time.Second, d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error waiting for network device %q to be restored from backup %q: %s", deviceID, backupID, err)
+	}
+	if err := d.Set(networkDeviceBackupRestoreSchemaNames["DeviceStatus"], resp.(*ne.Device).Status); err != nil {
+		return diag.Errorf("error reading %s: %s", networkDeviceBackupRestoreSchemaNames["DeviceStatus"], err)
+	}
+	return diags
+}
+
+func resourceNetworkDeviceBackupRestoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Restore is an action, there is nothing to refresh after it was done
+	return nil
+}
+
+func resourceNetworkDeviceBackupRestoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Restore can't be undone, the resource is only removed from the state
+	d.SetId("")
+	return nil
+}
+
+// createNetworkDeviceRestoreWaitConfiguration waits until the restored device
+// is provisioned again. The first check is delayed, as the device starts
+// restoring shortly after the restore request.
+func createNetworkDeviceRestoreWaitConfiguration(fetchFunc getDevice, id string, initialDelay time.Duration, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	pending := []string{
+		neDeviceStateRestoring,
+		ne.DeviceStateInitializing,
+		ne.DeviceStateProvisioning,
+	}
+	target := []string{
+		ne.DeviceStateProvisioned,
+	}
+	conf := createNetworkDeviceStatusWaitConfiguration(fetchFunc, id, delay, timeout, target, pending)
+	conf.Delay = initialDelay
+	return conf
+}
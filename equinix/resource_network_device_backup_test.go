@@ -0,0 +1,88 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDeviceBackup_updateResourceData(t *testing.T) {
+	// given
+	input := &networkDeviceBackup{
+		UUID:            ne.String("ab5e11a3-4bc4-4e8c-9e1a-23b4b4bc2e9f"),
+		Name:            ne.String("before-upgrade"),
+		DeviceUUID:      ne.String("8895983f-00f9-42f1-a387-85248f2aab49"),
+		Version:         ne.String("16.09.05"),
+		Type:            ne.String("CSR1000V"),
+		Status:          ne.String(neDeviceBackupStatusCompleted),
+		DownloadURL:     ne.String("/ne/v1/deviceBackups/ab5e11a3-4bc4-4e8c-9e1a-23b4b4bc2e9f/download"),
+		DeleteAllowed:   ne.Bool(true),
+		CreatedBy:       ne.String("user"),
+		LastUpdatedDate: ne.String("2023-06-01T10:00:00Z"),
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceBackupSchema(), make(map[string]interface{}))
+	// when
+	err := updateNetworkDeviceBackupResource(input, d)
+	// then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, ne.StringValue(input.Name), d.Get(networkDeviceBackupSchemaNames["Name"]), "Name matches")
+	assert.Equal(t, ne.StringValue(input.DeviceUUID), d.Get(networkDeviceBackupSchemaNames["DeviceUUID"]), "DeviceUUID matches")
+	assert.Equal(t, ne.StringValue(input.Version), d.Get(networkDeviceBackupSchemaNames["Version"]), "Version matches")
+	assert.Equal(t, ne.StringValue(input.Status), d.Get(networkDeviceBackupSchemaNames["Status"]), "Status matches")
+	assert.Equal(t, ne.StringValue(input.DownloadURL), d.Get(networkDeviceBackupSchemaNames["DownloadURL"]), "DownloadURL matches")
+	assert.Equal(t, true, d.Get(networkDeviceBackupSchemaNames["DeleteAllowed"]), "DeleteAllowed matches")
+}
+
+func TestNetworkDeviceBackup_statusWaitConfiguration(t *testing.T) {
+	// given
+	backupID := "test"
+	calls := 0
+	fetchFunc := func(uuid string) (*networkDeviceBackup, error) {
+		calls++
+		if calls < 2 {
+			return &networkDeviceBackup{Status: ne.String(neDeviceBackupStatusPending)}, nil
+		}
+		return &networkDeviceBackup{Status: ne.String(neDeviceBackupStatusCompleted)}, nil
+	}
+	failedFetchFunc := func(uuid string) (*networkDeviceBackup, error) {
+		return &networkDeviceBackup{Status: ne.String(neDeviceBackupStatusFailed)}, nil
+	}
+	delay := 100 * time.Millisecond
+	timeout := 10 * time.Minute
+	// when
+	waitConfig := createNetworkDeviceBackupStatusWaitConfiguration(fetchFunc, backupID, delay, timeout)
+	_, err := waitConfig.WaitForStateContext(context.Background())
+	_, failedErr := createNetworkDeviceBackupStatusWaitConfiguration(failedFetchFunc, backupID, delay, timeout).WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, 2, calls, "Backup is fetched until it is completed")
+	assert.Error(t, failedErr, "WaitForState returns an error for a failed backup")
+	assert.Equal(t, timeout, waitConfig.Timeout, "Backup status wait configuration timeout matches")
+	assert.Equal(t, delay, waitConfig.MinTimeout, "Backup status wait configuration min timeout matches")
+}
+
+func TestNetworkDeviceBackup_restoreWaitConfiguration(t *testing.T) {
+	// given
+	deviceID := "test"
+	statuses := []string{neDeviceStateRestoring, ne.DeviceStateProvisioning, ne.DeviceStateProvisioned}
+	calls := 0
+	fetchFunc := func(uuid string) (*ne.Device, error) {
+		status := statuses[calls]
+		calls++
+		return &ne.Device{Status: ne.String(status)}, nil
+	}
+	initialDelay := 10 * time.Millisecond
+	delay := 10 * time.Millisecond
+	timeout := time.Minute
+	// when
+	waitConfig := createNetworkDeviceRestoreWaitConfiguration(fetchFunc, deviceID, initialDelay, delay, timeout)
+	_, err := waitConfig.WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, 3, calls, "Device is fetched until it is provisioned")
+	assert.Equal(t, initialDelay, waitConfig.Delay, "Restore wait configuration delay matches")
+}
@@ -438,3 +438,27 @@ func TestNetworkDevice_AdditionalBandwidthStatusWaitConfiguration(t *testing.T)
 	assert.Equal(t, timeout, waitConfig.Timeout, "Additional bandwidth status wait configuration timeout matches")
 	assert.Equal(t, delay, waitConfig.MinTimeout, "Additional bandwidth wait configuration min timeout matches")
 }
+
+func TestNetworkDevice_upgradeOrder(t *testing.T) {
+	// given
+	single := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), map[string]interface{}{})
+	single.SetId("primary")
+	ha := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), map[string]interface{}{})
+	ha.SetId("primary")
+	_ = ha.Set(neDeviceSchemaNames["RedundantUUID"], "secondary")
+	cluster := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), map[string]interface{}{})
+	cluster.SetId("cluster")
+	_ = cluster.Set(neDeviceSchemaNames["ClusterDetails"], flattenNetworkDeviceClusterDetails(&ne.ClusterDetails{
+		ClusterName: ne.String("cluster"),
+		Node0:       &ne.ClusterNodeDetail{UUID: ne.String("node0")},
+		Node1:       &ne.ClusterNodeDetail{UUID: ne.String("node1")},
+	}))
+	// when
+	singleOrder := getNetworkDeviceUpgradeOrder(single)
+	haOrder := getNetworkDeviceUpgradeOrder(ha)
+	clusterOrder := getNetworkDeviceUpgradeOrder(cluster)
+	// then
+	assert.Equal(t, []string{"primary"}, singleOrder)
+	assert.Equal(t, []string{"secondary", "primary"}, haOrder)
+	assert.Equal(t, []string{"node1", "node0"}, clusterOrder)
+}
@@ -2,29 +2,42 @@ package equinix
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"math/big"
 
 	"github.com/equinix/ne-go"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var networkSSHUserSchemaNames = map[string]string{
-	"UUID":        "uuid",
-	"Username":    "username",
-	"Password":    "password",
-	"DeviceUUIDs": "device_ids",
+	"UUID":              "uuid",
+	"Username":          "username",
+	"Password":          "password",
+	"DeviceUUIDs":       "device_ids",
+	"GeneratePassword":  "generate_password",
+	"Keepers":           "keepers",
+	"WriteOnlyPassword": "write_only_password",
+	"PasswordHash":      "password_hash",
 }
 
 var networkSSHUserDescriptions = map[string]string{
-	"UUID":        "SSH user unique identifier",
-	"Username":    "SSH user login name",
-	"Password":    "SSH user password",
-	"DeviceUUIDs": "list of device identifiers to which user will have access",
+	"UUID":              "SSH user unique identifier",
+	"Username":          "SSH user login name",
+	"Password":          "SSH user password",
+	"DeviceUUIDs":       "list of device identifiers to which user will have access",
+	"GeneratePassword":  "Boolean value that determines if the password is generated by the provider instead of being given",
+	"Keepers":           "Arbitrary map of values that, when changed, generates a new password",
+	"WriteOnlyPassword": "Boolean value that determines if the password is kept out of the state, where only its hash is stored",
+	"PasswordHash":      "Salted bcrypt hash of the password applied on the SSH user",
 }
 
+const networkSSHUserGeneratedPasswordLength = 20
+
 func resourceNetworkSSHUser() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNetworkSSHUserCreate,
@@ -34,8 +47,9 @@ func resourceNetworkSSHUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Schema:      createNetworkSSHUserResourceSchema(),
-		Description: "Resource allows creation and management of Equinix Network Edge SSH users",
+		Schema:        createNetworkSSHUserResourceSchema(),
+		CustomizeDiff: checkNetworkSSHUserPassword,
+		Description:   "Resource allows creation and management of Equinix Network Edge SSH users",
 	}
 }
 
@@ -54,11 +68,37 @@ func createNetworkSSHUserResourceSchema() map[string]*schema.Schema {
 			Description:  networkSSHUserDescriptions["Username"],
 		},
 		networkSSHUserSchemaNames["Password"]: {
-			Type:         schema.TypeString,
-			Sensitive:    true,
-			Required:     true,
-			ValidateFunc: validation.StringLenBetween(8, 20),
-			Description:  networkSSHUserDescriptions["Password"],
+			Type:             schema.TypeString,
+			Sensitive:        true,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringLenBetween(8, 20),
+			DiffSuppressFunc: suppressNetworkSSHUserWriteOnlyPasswordDiff,
+			Description:      networkSSHUserDescriptions["Password"],
+		},
+		networkSSHUserSchemaNames["GeneratePassword"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: networkSSHUserDescriptions["GeneratePassword"],
+		},
+		networkSSHUserSchemaNames["Keepers"]: {
+			Type:         schema.TypeMap,
+			Optional:     true,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			RequiredWith: []string{networkSSHUserSchemaNames["GeneratePassword"]},
+			Description:  networkSSHUserDescriptions["Keepers"],
+		},
+		networkSSHUserSchemaNames["WriteOnlyPassword"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: networkSSHUserDescriptions["WriteOnlyPassword"],
+		},
+		networkSSHUserSchemaNames["PasswordHash"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkSSHUserDescriptions["PasswordHash"],
 		},
 		networkSSHUserSchemaNames["DeviceUUIDs"]: {
 			Type:     schema.TypeSet,
@@ -82,20 +122,24 @@ func resourceNetworkSSHUserCreate(ctx context.Context, d *schema.ResourceData, m
 	if len(user.DeviceUUIDs) < 0 {
 		return diag.Errorf("create ssh-user failed: user needs to have at least one device defined")
 	}
+	if d.Get(networkSSHUserSchemaNames["GeneratePassword"]).(bool) {
+		password, err := generateNetworkSSHUserPassword(networkSSHUserGeneratedPasswordLength)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		user.Password = ne.String(password)
+	}
 	uuid, err := client.CreateSSHUser(ne.StringValue(user.Username), ne.StringValue(user.Password), user.DeviceUUIDs[0])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(ne.StringValue(uuid))
-	userUpdateReq := client.NewSSHUserUpdateRequest(ne.StringValue(uuid))
-	userUpdateReq.WithDeviceChange([]string{}, user.DeviceUUIDs[1:len(user.DeviceUUIDs)])
-	if err := userUpdateReq.Execute(); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity:      diag.Warning,
-			Summary:       "Failed to assign all devices to newly created user",
-			Detail:        err.Error(),
-			AttributePath: cty.GetAttrPath(networkSSHUserSchemaNames["DeviceUUIDs"]),
-		})
+	if err := setNetworkSSHUserPassword(ne.StringValue(user.Password), d); err != nil {
+		return diag.FromErr(err)
+	}
+	for _, diagnostic := range changeNetworkSSHUserDevices(client, d.Id(), []string{}, user.DeviceUUIDs[1:]) {
+		diagnostic.Severity = diag.Warning
+		diags = append(diags, diagnostic)
 	}
 	diags = append(diags, resourceNetworkSSHUserRead(ctx, d, m)...)
 	return diags
@@ -119,18 +163,28 @@ func resourceNetworkSSHUserUpdate(ctx context.Context, d *schema.ResourceData, m
 	client := m.(*Config).ne
 	m.(*Config).addModuleToNEUserAgent(&client, d)
 	var diags diag.Diagnostics
-	updateReq := client.NewSSHUserUpdateRequest(d.Id())
-	if v, ok := d.GetOk(networkSSHUserSchemaNames["Password"]); ok && d.HasChange(networkSSHUserSchemaNames["Password"]) {
-		updateReq.WithNewPassword(v.(string))
+	password, err := getNetworkSSHUserNewPassword(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if password != "" {
+		if err := client.NewSSHUserUpdateRequest(d.Id()).WithNewPassword(password).Execute(); err != nil {
+			// the new password was not applied, so it must not get to the state
+			old, _ := d.GetChange(networkSSHUserSchemaNames["Password"])
+			if setErr := d.Set(networkSSHUserSchemaNames["Password"], old); setErr != nil {
+				return diag.FromErr(setErr)
+			}
+			return diag.Errorf("could not update password of SSH user %q: %s", d.Id(), err)
+		}
+	}
+	if err := setNetworkSSHUserPassword(password, d); err != nil {
+		return diag.FromErr(err)
 	}
 	if d.HasChange(networkSSHUserSchemaNames["DeviceUUIDs"]) {
 		a, b := d.GetChange(networkSSHUserSchemaNames["DeviceUUIDs"])
 		aList := expandSetToStringList(a.(*schema.Set))
 		bList := expandSetToStringList(b.(*schema.Set))
-		updateReq.WithDeviceChange(aList, bList)
-	}
-	if err := updateReq.Execute(); err != nil {
-		return diag.FromErr(err)
+		diags = append(diags, changeNetworkSSHUserDevices(client, d.Id(), aList, bList)...)
 	}
 	diags = append(diags, resourceNetworkSSHUserRead(ctx, d, m)...)
 	return diags
@@ -180,3 +234,169 @@ func updateNetworkSSHUserResource(user *ne.SSHUser, d *schema.ResourceData) erro
 	}
 	return nil
 }
+
+// checkNetworkSSHUserPassword requires either a password or its generation, and
+// marks the password as computed when a new one is going to be generated.
+func checkNetworkSSHUserPassword(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	passwordInConfig := !d.GetRawConfig().GetAttr(networkSSHUserSchemaNames["Password"]).IsNull()
+	if !d.Get(networkSSHUserSchemaNames["GeneratePassword"]).(bool) {
+		if !passwordInConfig {
+			return fmt.Errorf("%s has to be set when %s is not enabled", networkSSHUserSchemaNames["Password"], networkSSHUserSchemaNames["GeneratePassword"])
+		}
+		if d.HasChange(networkSSHUserSchemaNames["Password"]) {
+			return d.SetNewComputed(networkSSHUserSchemaNames["PasswordHash"])
+		}
+		return nil
+	}
+	if passwordInConfig {
+		return fmt.Errorf("%s can't be set when %s is enabled", networkSSHUserSchemaNames["Password"], networkSSHUserSchemaNames["GeneratePassword"])
+	}
+	if d.Get(networkSSHUserSchemaNames["WriteOnlyPassword"]).(bool) {
+		return fmt.Errorf("%s can't be enabled together with %s, as a generated password is only kept in the state", networkSSHUserSchemaNames["WriteOnlyPassword"], networkSSHUserSchemaNames["GeneratePassword"])
+	}
+	if d.Id() == "" || d.HasChange(networkSSHUserSchemaNames["Keepers"]) || d.HasChange(networkSSHUserSchemaNames["GeneratePassword"]) {
+		if err := d.SetNewComputed(networkSSHUserSchemaNames["Password"]); err != nil {
+			return err
+		}
+		return d.SetNewComputed(networkSSHUserSchemaNames["PasswordHash"])
+	}
+	return nil
+}
+
+// suppressNetworkSSHUserWriteOnlyPasswordDiff suppresses the password diff of
+// a write-only password, which is not in the state, when its hash has not
+// changed.
+func suppressNetworkSSHUserWriteOnlyPasswordDiff(k, old, new string, d *schema.ResourceData) bool {
+	if !d.Get(networkSSHUserSchemaNames["WriteOnlyPassword"]).(bool) || new == "" {
+		return false
+	}
+	return checkNetworkSSHUserPasswordHash(d.Get(networkSSHUserSchemaNames["PasswordHash"]).(string), new)
+}
+
+// getNetworkSSHUserNewPassword returns the password to apply on an existing
+// SSH user, or an empty string when the password is not changed.
+func getNetworkSSHUserNewPassword(d resourceDataProvider) (string, error) {
+	if d.Get(networkSSHUserSchemaNames["GeneratePassword"]).(bool) {
+		if d.HasChange(networkSSHUserSchemaNames["Keepers"]) || d.HasChange(networkSSHUserSchemaNames["GeneratePassword"]) {
+			return generateNetworkSSHUserPassword(networkSSHUserGeneratedPasswordLength)
+		}
+		return "", nil
+	}
+	if d.HasChange(networkSSHUserSchemaNames["Password"]) {
+		return d.Get(networkSSHUserSchemaNames["Password"]).(string), nil
+	}
+	return "", nil
+}
+
+// setNetworkSSHUserPassword stores the applied password and its hash, or the
+// hash of the unchanged password when it is missing. Write-only passwords are
+// removed from the state, leaving only the hash.
+func setNetworkSSHUserPassword(password string, d *schema.ResourceData) error {
+	if password == "" && d.Get(networkSSHUserSchemaNames["PasswordHash"]).(string) == "" {
+		if current := d.Get(networkSSHUserSchemaNames["Password"]).(string); current != "" {
+			hash, err := hashNetworkSSHUserPassword(current)
+			if err != nil {
+				return err
+			}
+			if err := d.Set(networkSSHUserSchemaNames["PasswordHash"], hash); err != nil {
+				return fmt.Errorf("error reading PasswordHash: %s", err)
+			}
+		}
+	}
+	if password != "" {
+		if err := d.Set(networkSSHUserSchemaNames["Password"], password); err != nil {
+			return fmt.Errorf("error reading Password: %s", err)
+		}
+		hash, err := hashNetworkSSHUserPassword(password)
+		if err != nil {
+			return err
+		}
+		if err := d.Set(networkSSHUserSchemaNames["PasswordHash"], hash); err != nil {
+			return fmt.Errorf("error reading PasswordHash: %s", err)
+		}
+	}
+	if d.Get(networkSSHUserSchemaNames["WriteOnlyPassword"]).(bool) {
+		if err := d.Set(networkSSHUserSchemaNames["Password"], ""); err != nil {
+			return fmt.Errorf("error reading Password: %s", err)
+		}
+	}
+	return nil
+}
+
+// changeNetworkSSHUserDevices associates and unassociates devices one at a
+// time and returns an error diagnostic for each device that failed, so that
+// changes of other devices are still applied.
+func changeNetworkSSHUserDevices(client ne.Client, uuid string, oldDevices, newDevices []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	path := cty.GetAttrPath(networkSSHUserSchemaNames["DeviceUUIDs"])
+	for _, device := range newDevices {
+		if isStringInSlice(device, oldDevices) {
+			continue
+		}
+		if err := client.NewSSHUserUpdateRequest(uuid).WithDeviceChange([]string{}, []string{device}).Execute(); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Failed to assign device %q to SSH user", device),
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+	}
+	for _, device := range oldDevices {
+		if isStringInSlice(device, newDevices) {
+			continue
+		}
+		if err := client.NewSSHUserUpdateRequest(uuid).WithDeviceChange([]string{device}, []string{}).Execute(); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Failed to unassign device %q from SSH user", device),
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+	}
+	return diags
+}
+
+// hashNetworkSSHUserPassword returns a bcrypt hash of the password, which is
+// salted and slow to compute, so that the password kept out of the state can't
+// be recovered from the hash.
+func hashNetworkSSHUserPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash SSH user password: %s", err)
+	}
+	return string(hash), nil
+}
+
+func checkNetworkSSHUserPasswordHash(hash, password string) bool {
+	return hash != "" && bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// generateNetworkSSHUserPassword returns a random password with at least one
+// lower case letter, upper case letter and digit.
+func generateNetworkSSHUserPassword(length int) (string, error) {
+	classes := []string{"abcdefghijkmnopqrstuvwxyz", "ABCDEFGHJKLMNPQRSTUVWXYZ", "23456789"}
+	all := classes[0] + classes[1] + classes[2]
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", fmt.Errorf("could not generate password: %s", err)
+		}
+		password[i] = charset[n.Int64()]
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("could not generate password: %s", err)
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
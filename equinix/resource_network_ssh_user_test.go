@@ -46,3 +46,90 @@ func TestNetworkSSHUser_updateResourceData(t *testing.T) {
 	assert.Equal(t, ne.StringValue(input.Password), d.Get(networkSSHUserSchemaNames["Password"]), "Password matches")
 	assert.Equal(t, input.DeviceUUIDs, expandSetToStringList(d.Get(networkSSHUserSchemaNames["DeviceUUIDs"]).(*schema.Set)), "DeviceUUIDs matches")
 }
+
+func TestNetworkSSHUser_generatePassword(t *testing.T) {
+	// when
+	first, err := generateNetworkSSHUserPassword(networkSSHUserGeneratedPasswordLength)
+	second, _ := generateNetworkSSHUserPassword(networkSSHUserGeneratedPasswordLength)
+	// then
+	assert.Nil(t, err, "Generation does not return error")
+	assert.Len(t, first, networkSSHUserGeneratedPasswordLength, "Password has requested length")
+	assert.Regexp(t, "[a-z]", first, "Password contains lower case letter")
+	assert.Regexp(t, "[A-Z]", first, "Password contains upper case letter")
+	assert.Regexp(t, "[0-9]", first, "Password contains digit")
+	assert.NotEqual(t, first, second, "Generated passwords differ")
+}
+
+func TestNetworkSSHUser_getNewPassword(t *testing.T) {
+	// given
+	keepersChange := mockedResourceDataProvider{
+		actual: map[string]interface{}{
+			networkSSHUserSchemaNames["GeneratePassword"]: true,
+			networkSSHUserSchemaNames["Keepers"]:          map[string]interface{}{"rotation": "2"},
+			networkSSHUserSchemaNames["Password"]:         "oldSecret",
+		},
+		old: map[string]interface{}{
+			networkSSHUserSchemaNames["GeneratePassword"]: true,
+			networkSSHUserSchemaNames["Keepers"]:          map[string]interface{}{"rotation": "1"},
+			networkSSHUserSchemaNames["Password"]:         "oldSecret",
+		},
+	}
+	noKeepersChange := mockedResourceDataProvider{
+		actual: keepersChange.old,
+		old:    keepersChange.old,
+	}
+	passwordChange := mockedResourceDataProvider{
+		actual: map[string]interface{}{
+			networkSSHUserSchemaNames["GeneratePassword"]: false,
+			networkSSHUserSchemaNames["Password"]:         "newSecret",
+		},
+		old: map[string]interface{}{
+			networkSSHUserSchemaNames["GeneratePassword"]: false,
+			networkSSHUserSchemaNames["Password"]:         "oldSecret",
+		},
+	}
+	// when
+	generated, err := getNetworkSSHUserNewPassword(keepersChange)
+	unchanged, _ := getNetworkSSHUserNewPassword(noKeepersChange)
+	changed, _ := getNetworkSSHUserNewPassword(passwordChange)
+	// then
+	assert.Nil(t, err, "Getting new password does not return error")
+	assert.Len(t, generated, networkSSHUserGeneratedPasswordLength, "Password is generated on keepers change")
+	assert.NotEqual(t, "oldSecret", generated, "Generated password differs from old one")
+	assert.Empty(t, unchanged, "Password is not generated without keepers change")
+	assert.Equal(t, "newSecret", changed, "Changed password is returned")
+}
+
+func TestNetworkSSHUser_setPassword(t *testing.T) {
+	// given
+	d := schema.TestResourceDataRaw(t, createNetworkSSHUserResourceSchema(), map[string]interface{}{
+		networkSSHUserSchemaNames["Password"]: "oldSecret",
+	})
+	writeOnly := schema.TestResourceDataRaw(t, createNetworkSSHUserResourceSchema(), map[string]interface{}{
+		networkSSHUserSchemaNames["Password"]:          "secret",
+		networkSSHUserSchemaNames["WriteOnlyPassword"]: true,
+	})
+	// when
+	err := setNetworkSSHUserPassword("newSecret", d)
+	writeOnlyErr := setNetworkSSHUserPassword("", writeOnly)
+	// then
+	assert.Nil(t, err, "Setting password does not return error")
+	assert.Equal(t, "newSecret", d.Get(networkSSHUserSchemaNames["Password"]), "Password matches")
+	assert.True(t, checkNetworkSSHUserPasswordHash(d.Get(networkSSHUserSchemaNames["PasswordHash"]).(string), "newSecret"), "PasswordHash matches")
+	assert.Nil(t, writeOnlyErr, "Setting write-only password does not return error")
+	assert.Empty(t, writeOnly.Get(networkSSHUserSchemaNames["Password"]), "Write-only password is removed")
+	assert.True(t, checkNetworkSSHUserPasswordHash(writeOnly.Get(networkSSHUserSchemaNames["PasswordHash"]).(string), "secret"), "Write-only PasswordHash matches")
+}
+
+func TestNetworkSSHUser_hashPassword(t *testing.T) {
+	// when
+	hash, err := hashNetworkSSHUserPassword("secret")
+	otherHash, otherErr := hashNetworkSSHUserPassword("secret")
+	// then
+	assert.Nil(t, err, "Hashing password does not return error")
+	assert.Nil(t, otherErr, "Hashing password again does not return error")
+	assert.NotEqual(t, hash, otherHash, "Hashes of the same password are salted")
+	assert.True(t, checkNetworkSSHUserPasswordHash(hash, "secret"), "Password matches its hash")
+	assert.False(t, checkNetworkSSHUserPasswordHash(hash, "other"), "Other password does not match the hash")
+	assert.False(t, checkNetworkSSHUserPasswordHash("", "secret"), "Password does not match a missing hash")
+}